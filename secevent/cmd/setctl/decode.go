@@ -0,0 +1,85 @@
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"strings"
+)
+
+func runDecode(args []string, stdin io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("decode", flag.ContinueOnError)
+	in := fs.String("in", "", "file containing the token (default: argument or stdin)")
+
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: setctl decode [-in file] [token]\n\nPrints the header and claims of a SET without verifying its signature.")
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	tokenString, err := readToken(*in, fs.Args(), stdin)
+	if err != nil {
+		return err
+	}
+
+	decoded, err := decodeToken(tokenString)
+	if err != nil {
+		return err
+	}
+
+	return writeJSON(stdout, decoded)
+}
+
+type decodedToken struct {
+	Header    json.RawMessage `json:"header"`
+	Claims    json.RawMessage `json:"claims,omitempty"`
+	Encrypted bool            `json:"encrypted,omitempty"`
+}
+
+// decodeToken decodes the segments of a JWS, or the protected header of a JWE
+func decodeToken(tokenString string) (*decodedToken, error) {
+	parts := strings.Split(tokenString, ".")
+
+	switch len(parts) {
+	case 3:
+	case 5:
+		header, err := decodeSegment(parts[0], "header")
+		if err != nil {
+			return nil, err
+		}
+
+		return &decodedToken{Header: header, Encrypted: true}, nil
+	default:
+		return nil, fmt.Errorf("token has %d segments, expected 3 (JWS) or 5 (JWE)", len(parts))
+	}
+
+	header, err := decodeSegment(parts[0], "header")
+	if err != nil {
+		return nil, err
+	}
+
+	claims, err := decodeSegment(parts[1], "claims")
+	if err != nil {
+		return nil, err
+	}
+
+	return &decodedToken{Header: header, Claims: claims}, nil
+}
+
+func decodeSegment(segment, name string) (json.RawMessage, error) {
+	data, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(segment, "="))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode %s: %w", name, err)
+	}
+
+	if !json.Valid(data) {
+		return nil, fmt.Errorf("%s is not valid JSON", name)
+	}
+
+	return data, nil
+}
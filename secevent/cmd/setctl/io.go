@@ -0,0 +1,82 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+)
+
+// errUsage is returned after a flag set has already reported a usage error
+var errUsage = errors.New("usage error")
+
+// errHelp is returned after a flag set has printed its usage in response to -h or -help
+var errHelp = errors.New("help requested")
+
+func parseFlags(fs *flag.FlagSet, args []string) error {
+	if err := fs.Parse(args); err != nil {
+		if errors.Is(err, flag.ErrHelp) {
+			return errHelp
+		}
+
+		return errUsage
+	}
+
+	return nil
+}
+
+// readToken reads a compact token from the given file, the first positional
+// argument, or stdin, in that order
+func readToken(path string, positional []string, stdin io.Reader) (string, error) {
+	var (
+		data []byte
+		err  error
+	)
+
+	switch {
+	case path != "":
+		data, err = os.ReadFile(path)
+	case len(positional) > 0 && positional[0] != "-":
+		data = []byte(positional[0])
+	default:
+		data, err = io.ReadAll(stdin)
+	}
+
+	if err != nil {
+		return "", fmt.Errorf("failed to read token: %w", err)
+	}
+
+	tokenString := strings.TrimSpace(string(data))
+	if tokenString == "" {
+		return "", fmt.Errorf("no token provided")
+	}
+
+	return tokenString, nil
+}
+
+func writeJSON(w io.Writer, v interface{}) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+
+	return encoder.Encode(v)
+}
+
+// stringList is a repeatable string flag that also accepts comma-separated values
+type stringList []string
+
+func (l *stringList) String() string {
+	return strings.Join(*l, ",")
+}
+
+func (l *stringList) Set(value string) error {
+	for _, v := range strings.Split(value, ",") {
+		if v = strings.TrimSpace(v); v != "" {
+			*l = append(*l, v)
+		}
+	}
+
+	return nil
+}
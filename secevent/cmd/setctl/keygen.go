@@ -0,0 +1,127 @@
+package main
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/signing"
+)
+
+func runKeygen(args []string, _ io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("keygen", flag.ContinueOnError)
+	keyType := fs.String("type", "ec", "key type: ec, rsa or ed25519")
+	curve := fs.String("curve", "P-256", "EC curve: P-256, P-384 or P-521")
+	bits := fs.Int("bits", 2048, "RSA key size")
+	kid := fs.String("kid", "", "key ID (default: JWK SHA-256 thumbprint)")
+	out := fs.String("out", "", "file to write the PEM private key to (required)")
+	jwksPath := fs.String("jwks", "", "file to write the public JWKS to (default: stdout)")
+
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: setctl keygen -out key.pem [flags]\n\nGenerates a signing key and the JWKS receivers use to verify it.")
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return fmt.Errorf("-out is required")
+	}
+
+	key, err := generateKey(*keyType, *curve, *bits)
+	if err != nil {
+		return err
+	}
+
+	keyID := *kid
+	if keyID == "" {
+		if keyID, err = thumbprint(key.Public()); err != nil {
+			return err
+		}
+	}
+
+	manager := signing.NewKeyManager()
+	if err := manager.AddKey(keyID, key); err != nil {
+		return err
+	}
+
+	keySet, err := manager.PublicJWKS()
+	if err != nil {
+		return err
+	}
+
+	jwksJSON, err := json.MarshalIndent(keySet, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed to encode JWKS: %w", err)
+	}
+
+	if err := writePrivateKey(*out, key); err != nil {
+		return err
+	}
+
+	if *jwksPath == "" {
+		_, err = fmt.Fprintln(stdout, string(jwksJSON))
+
+		return err
+	}
+
+	return os.WriteFile(*jwksPath, append(jwksJSON, '\n'), 0o644)
+}
+
+func generateKey(keyType, curve string, bits int) (crypto.Signer, error) {
+	switch keyType {
+	case "ec":
+		var c elliptic.Curve
+
+		switch curve {
+		case "P-256":
+			c = elliptic.P256()
+		case "P-384":
+			c = elliptic.P384()
+		case "P-521":
+			c = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", curve)
+		}
+
+		return ecdsa.GenerateKey(c, rand.Reader)
+	case "rsa":
+		if bits < 2048 {
+			return nil, fmt.Errorf("RSA keys must be at least 2048 bits")
+		}
+
+		return rsa.GenerateKey(rand.Reader, bits)
+	case "ed25519":
+		_, key, err := ed25519.GenerateKey(rand.Reader)
+
+		return key, err
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", keyType)
+	}
+}
+
+func thumbprint(publicKey crypto.PublicKey) (string, error) {
+	key, err := jwk.FromRaw(publicKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to convert key: %w", err)
+	}
+
+	sum, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute thumbprint: %w", err)
+	}
+
+	return base64.RawURLEncoding.EncodeToString(sum), nil
+}
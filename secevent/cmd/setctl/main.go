@@ -0,0 +1,81 @@
+// Command setctl builds, signs, decodes and verifies Security Event Tokens.
+//
+// Usage:
+//
+//	setctl decode [-in token.jwt]
+//	setctl verify -jwks <file|url> [-issuer iss] [-audience aud] [-in token.jwt]
+//	setctl sign -spec event.yaml -key key.pem [-kid kid] [-alg alg]
+//	setctl keygen [-type ec|rsa|ed25519] -out key.pem -jwks jwks.json
+//
+// Tokens are read from -in, the first argument, or standard input.
+package main
+
+import (
+	"fmt"
+	"io"
+	"os"
+)
+
+const usage = `setctl builds, signs, decodes and verifies Security Event Tokens (SETs).
+
+Usage:
+  setctl <command> [flags]
+
+Commands:
+  decode   Print the header and claims of a SET without verifying it
+  verify   Verify a SET against a JWKS and print its claims
+  sign     Build and sign a SET from a YAML or JSON spec
+  keygen   Generate a signing key and the matching JWKS
+
+Run "setctl <command> -h" for the flags of a command.
+`
+
+type command func(args []string, stdin io.Reader, stdout io.Writer) error
+
+var commands = map[string]command{
+	"decode": runDecode,
+	"verify": runVerify,
+	"sign":   runSign,
+	"keygen": runKeygen,
+}
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	if len(args) == 0 {
+		fmt.Fprint(stderr, usage)
+
+		return 2
+	}
+
+	if args[0] == "-h" || args[0] == "--help" || args[0] == "help" {
+		fmt.Fprint(stdout, usage)
+
+		return 0
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		fmt.Fprintf(stderr, "setctl: unknown command %q\n\n%s", args[0], usage)
+
+		return 2
+	}
+
+	if err := cmd(args[1:], stdin, stdout); err != nil {
+		if err == errHelp {
+			return 0
+		}
+
+		if err == errUsage {
+			return 2
+		}
+
+		fmt.Fprintf(stderr, "setctl %s: %v\n", args[0], err)
+
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,187 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testSpec = `iss: https://issuer.example.com
+aud:
+  - https://receiver.example.com
+jti: test-jti
+sub_id:
+  format: email
+  email: user@example.com
+events:
+  https://schemas.openid.net/secevent/caep/event-type/session-revoked:
+    event_timestamp: 1700000000
+`
+
+func runCommand(t *testing.T, stdin string, args ...string) (string, int) {
+	t.Helper()
+
+	var stdout, stderr bytes.Buffer
+
+	code := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	if code != 0 {
+		t.Logf("setctl %s: %s", strings.Join(args, " "), stderr.String())
+	}
+
+	return stdout.String(), code
+}
+
+func TestSetctl_KeygenSignVerifyDecode(t *testing.T) {
+	for _, keyType := range []string{"ec", "rsa", "ed25519"} {
+		t.Run(keyType, func(t *testing.T) {
+			dir := t.TempDir()
+			keyPath := filepath.Join(dir, "key.pem")
+			jwksPath := filepath.Join(dir, "jwks.json")
+			specPath := filepath.Join(dir, "event.yaml")
+
+			if err := os.WriteFile(specPath, []byte(testSpec), 0o600); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, code := runCommand(t, "", "keygen", "-type", keyType, "-kid", "key-1", "-out", keyPath, "-jwks", jwksPath); code != 0 {
+				t.Fatalf("keygen exited with %d", code)
+			}
+
+			signed, code := runCommand(t, "", "sign", "-spec", specPath, "-key", keyPath, "-kid", "key-1")
+			if code != 0 {
+				t.Fatalf("sign exited with %d", code)
+			}
+
+			verified, code := runCommand(t, signed, "verify", "-jwks", jwksPath,
+				"-issuer", "https://issuer.example.com", "-audience", "https://receiver.example.com")
+			if code != 0 {
+				t.Fatalf("verify exited with %d", code)
+			}
+
+			var claims map[string]interface{}
+			if err := json.Unmarshal([]byte(verified), &claims); err != nil {
+				t.Fatalf("verify output is not JSON: %v", err)
+			}
+
+			if claims["jti"] != "test-jti" {
+				t.Errorf("jti = %v", claims["jti"])
+			}
+
+			if _, code := runCommand(t, signed, "verify", "-jwks", jwksPath, "-issuer", "https://other.example.com"); code != 1 {
+				t.Errorf("verify with wrong issuer exited with %d, want 1", code)
+			}
+
+			decoded, code := runCommand(t, "", "decode", strings.TrimSpace(signed))
+			if code != 0 {
+				t.Fatalf("decode exited with %d", code)
+			}
+
+			var out decodedToken
+			if err := json.Unmarshal([]byte(decoded), &out); err != nil {
+				t.Fatalf("decode output is not JSON: %v", err)
+			}
+
+			var header map[string]interface{}
+			if err := json.Unmarshal(out.Header, &header); err != nil || header["kid"] != "key-1" {
+				t.Errorf("unexpected header %s", out.Header)
+			}
+		})
+	}
+}
+
+func TestSetctl_VerifyInvalidJWKS(t *testing.T) {
+	jwksPath := filepath.Join(t.TempDir(), "jwks.json")
+	if err := os.WriteFile(jwksPath, []byte(`{"keys": [`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	var stdout, stderr bytes.Buffer
+
+	if code := run([]string{"verify", "-jwks", jwksPath, "a.b.c"}, strings.NewReader(""), &stdout, &stderr); code != 1 {
+		t.Errorf("verify exited with %d, want 1", code)
+	}
+
+	if !strings.Contains(stderr.String(), "invalid JWKS") {
+		t.Errorf("stderr = %q, want it to report an invalid JWKS", stderr.String())
+	}
+}
+
+func TestSetctl_SignJSONSpecWithMultipleEvents(t *testing.T) {
+	dir := t.TempDir()
+	keyPath := filepath.Join(dir, "key.pem")
+	specPath := filepath.Join(dir, "event.json")
+
+	spec := `{
+  "iss": "https://issuer.example.com",
+  "sub_id": {"format": "opaque", "id": "user-1"},
+  "events": {
+    "https://schemas.openid.net/secevent/caep/event-type/session-revoked": {},
+    "https://schemas.openid.net/secevent/caep/event-type/session-established": {"amr": ["pwd"]}
+  }
+}`
+
+	if err := os.WriteFile(specPath, []byte(spec), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, code := runCommand(t, "", "keygen", "-out", keyPath); code != 0 {
+		t.Fatalf("keygen exited with %d", code)
+	}
+
+	signed, code := runCommand(t, "", "sign", "-spec", specPath, "-key", keyPath)
+	if code != 0 {
+		t.Fatalf("sign exited with %d", code)
+	}
+
+	decoded, err := decodeToken(strings.TrimSpace(signed))
+	if err != nil {
+		t.Fatalf("decodeToken() error = %v", err)
+	}
+
+	var claims struct {
+		Events map[string]json.RawMessage `json:"events"`
+	}
+
+	if err := json.Unmarshal(decoded.Claims, &claims); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(claims.Events) != 2 {
+		t.Errorf("got %d events, want 2", len(claims.Events))
+	}
+}
+
+func TestSetctl_Usage(t *testing.T) {
+	if _, code := runCommand(t, ""); code != 2 {
+		t.Errorf("no arguments exited with %d, want 2", code)
+	}
+
+	if _, code := runCommand(t, "", "bogus"); code != 2 {
+		t.Errorf("unknown command exited with %d, want 2", code)
+	}
+
+	if _, code := runCommand(t, "", "decode", "not-a-token"); code != 1 {
+		t.Errorf("invalid token exited with %d, want 1", code)
+	}
+}
+
+func TestSetctl_CommandHelp(t *testing.T) {
+	for name := range commands {
+		for _, flagName := range []string{"-h", "-help"} {
+			t.Run(name+flagName, func(t *testing.T) {
+				var stdout, stderr bytes.Buffer
+
+				if code := run([]string{name, flagName}, strings.NewReader(""), &stdout, &stderr); code != 0 {
+					t.Errorf("setctl %s %s exited with %d, want 0", name, flagName, code)
+				}
+
+				if stdout.Len() != 0 {
+					t.Errorf("setctl %s %s ran the command: %s", name, flagName, stdout.String())
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,186 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/builder"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/signing"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+	"gopkg.in/yaml.v3"
+)
+
+// eventSpec describes a SET to build. It uses the claim names of the token itself and is
+// read as YAML, which also accepts JSON.
+//
+//	iss: https://issuer.example.com
+//	aud: [https://receiver.example.com]
+//	sub_id:
+//	  format: email
+//	  email: user@example.com
+//	events:
+//	  https://schemas.openid.net/secevent/caep/event-type/session-revoked:
+//	    event_timestamp: 1700000000
+type eventSpec struct {
+	Issuer        string                            `yaml:"iss"`
+	Audience      []string                          `yaml:"aud"`
+	ID            string                            `yaml:"jti"`
+	IssuedAt      int64                             `yaml:"iat"`
+	TransactionID string                            `yaml:"txn"`
+	Subject       map[string]interface{}            `yaml:"sub_id"`
+	Events        map[string]map[string]interface{} `yaml:"events"`
+}
+
+func runSign(args []string, _ io.Reader, stdout io.Writer) error {
+	fs := flag.NewFlagSet("sign", flag.ContinueOnError)
+	specPath := fs.String("spec", "", "YAML or JSON event spec (required)")
+	keyPath := fs.String("key", "", "PEM private key (required)")
+	kid := fs.String("kid", "", "key ID to put in the token header")
+	alg := fs.String("alg", "", "signing algorithm (default: derived from the key)")
+
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: setctl sign -spec event.yaml -key key.pem [flags]\n\nBuilds a SET from a spec and prints the signed token.")
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *specPath == "" || *keyPath == "" {
+		return fmt.Errorf("-spec and -key are required")
+	}
+
+	specData, err := os.ReadFile(*specPath)
+	if err != nil {
+		return fmt.Errorf("failed to read spec: %w", err)
+	}
+
+	claims, err := buildClaims(specData)
+	if err != nil {
+		return err
+	}
+
+	key, err := loadPrivateKey(*keyPath)
+	if err != nil {
+		return err
+	}
+
+	signerOpts := []signing.SignerOption{}
+
+	if *kid != "" {
+		signerOpts = append(signerOpts, signing.WithKeyID(*kid))
+	}
+
+	if *alg != "" {
+		method := jwt.GetSigningMethod(*alg)
+		if method == nil {
+			return fmt.Errorf("unknown signing algorithm %q", *alg)
+		}
+
+		signerOpts = append(signerOpts, signing.WithSigningMethod(method))
+	}
+
+	signer, err := signing.NewSigner(key, signerOpts...)
+	if err != nil {
+		return fmt.Errorf("failed to create signer: %w", err)
+	}
+
+	signed, err := signer.Sign(claims)
+	if err != nil {
+		return fmt.Errorf("failed to sign: %w", err)
+	}
+
+	_, err = fmt.Fprintln(stdout, signed)
+
+	return err
+}
+
+// buildClaims builds a SecEvent, or a MultiSecEvent when the spec has several events
+func buildClaims(specData []byte) (jwt.Claims, error) {
+	var spec eventSpec
+	if err := yaml.Unmarshal(specData, &spec); err != nil {
+		return nil, fmt.Errorf("failed to parse spec: %w", err)
+	}
+
+	if len(spec.Events) == 0 {
+		return nil, fmt.Errorf("spec must contain at least one event")
+	}
+
+	subjectJSON, err := json.Marshal(spec.Subject)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sub_id: %w", err)
+	}
+
+	sub, err := subject.ParseSubject(subjectJSON)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sub_id: %w", err)
+	}
+
+	events := make([]event.Event, 0, len(spec.Events))
+
+	for eventType, payload := range spec.Events {
+		if payload == nil {
+			payload = map[string]interface{}{}
+		}
+
+		eventJSON, err := json.Marshal(payload)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event %s: %w", eventType, err)
+		}
+
+		evt, err := event.ParseEvent(event.EventType(eventType), eventJSON)
+		if err != nil {
+			return nil, fmt.Errorf("invalid event %s: %w", eventType, err)
+		}
+
+		events = append(events, evt)
+	}
+
+	b := builder.NewBuilder(builder.WithDefaultIssuer(spec.Issuer))
+
+	var issuedAt *jwt.NumericDate
+	if spec.IssuedAt != 0 {
+		issuedAt = jwt.NewNumericDate(time.Unix(spec.IssuedAt, 0))
+	}
+
+	if len(events) == 1 {
+		secEvent := b.NewSecEvent().WithSubject(sub).WithEvent(events[0])
+		applySpec(&secEvent.RegisteredClaims, &secEvent.TransactionID, spec, issuedAt)
+
+		return secEvent, secEvent.Validate()
+	}
+
+	secEvent := b.NewMultiSecEvent().WithSubject(sub)
+	for _, evt := range events {
+		secEvent.WithEvent(evt)
+	}
+
+	applySpec(&secEvent.RegisteredClaims, &secEvent.TransactionID, spec, issuedAt)
+
+	return secEvent, secEvent.Validate()
+}
+
+func applySpec(claims *jwt.RegisteredClaims, txn **string, spec eventSpec, issuedAt *jwt.NumericDate) {
+	if len(spec.Audience) > 0 {
+		claims.Audience = spec.Audience
+	}
+
+	if spec.ID != "" {
+		claims.ID = spec.ID
+	}
+
+	if issuedAt != nil {
+		claims.IssuedAt = issuedAt
+	}
+
+	if spec.TransactionID != "" {
+		*txn = &spec.TransactionID
+	}
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/parser"
+)
+
+func runVerify(args []string, stdin io.Reader, stdout io.Writer) error {
+	var audience stringList
+
+	fs := flag.NewFlagSet("verify", flag.ContinueOnError)
+	in := fs.String("in", "", "file containing the token (default: argument or stdin)")
+	jwks := fs.String("jwks", "", "JWKS file or http(s) URL (required)")
+	issuer := fs.String("issuer", "", "expected issuer")
+	fs.Var(&audience, "audience", "expected audience (repeatable or comma-separated)")
+	maxAge := fs.Duration("max-age", 0, "reject tokens whose iat is older than this (e.g. 10m)")
+	decryptionKey := fs.String("decryption-key", "", "PEM private key for encrypted tokens")
+	lenient := fs.Bool("lenient", false, "accept event types without a registered parser")
+	strict := fs.Bool("strict", false, "enforce the RFC 8417 / SSF SET profile (typ, no sub or exp, sub_id required)")
+
+	fs.Usage = func() {
+		fmt.Fprintln(fs.Output(), "Usage: setctl verify -jwks <file|url> [flags] [token]\n\nVerifies a SET and prints its claims.")
+		fs.PrintDefaults()
+	}
+
+	if err := parseFlags(fs, args); err != nil {
+		return err
+	}
+
+	if *jwks == "" {
+		return fmt.Errorf("-jwks is required")
+	}
+
+	tokenString, err := readToken(*in, fs.Args(), stdin)
+	if err != nil {
+		return err
+	}
+
+	opts := []parser.Option{}
+
+	if strings.HasPrefix(*jwks, "https://") || strings.HasPrefix(*jwks, "http://") {
+		opts = append(opts, parser.WithJWKSURL(*jwks))
+	} else {
+		jwksJSON, err := os.ReadFile(*jwks)
+		if err != nil {
+			return fmt.Errorf("failed to read JWKS: %w", err)
+		}
+
+		if _, err := jwk.Parse(jwksJSON); err != nil {
+			return fmt.Errorf("invalid JWKS: %w", err)
+		}
+
+		opts = append(opts, parser.WithJWKSJSON(jwksJSON))
+	}
+
+	if *issuer != "" {
+		opts = append(opts, parser.WithExpectedIssuer(*issuer))
+	}
+
+	if len(audience) > 0 {
+		opts = append(opts, parser.WithExpectedAudience(audience...))
+	}
+
+	if *maxAge > 0 {
+		opts = append(opts, parser.WithMaxTokenAge(*maxAge), parser.WithClockSkew(time.Minute))
+	}
+
+	if *decryptionKey != "" {
+		key, err := loadPrivateKey(*decryptionKey)
+		if err != nil {
+			return err
+		}
+
+		opts = append(opts, parser.WithDecryptionKey(key))
+	}
+
+	if *lenient {
+		opts = append(opts, parser.WithLenientEvents())
+	}
+
+	if *strict {
+		opts = append(opts, parser.WithStrictProfile())
+	}
+
+	secEvent, err := parser.NewParser(opts...).ParseMultiSecEvent(tokenString)
+	if err != nil {
+		return fmt.Errorf("verification failed: %w", err)
+	}
+
+	return writeJSON(stdout, secEvent)
+}
@@ -1,7 +1,15 @@
 package builder
 
 import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
 	"github.com/sgnl-ai/caep.dev/secevent/pkg/id"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/signing"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
 	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
 )
 
@@ -9,6 +17,12 @@ import (
 type Builder struct {
 	defaultIssuer      string
 	defaultIDGenerator id.Generator
+	defaultAudience    []string
+	defaultSigner      signing.Signer
+	txnGenerator       id.Generator
+	clock              func() time.Time
+	extensions         map[string]json.RawMessage
+	encodeOptions      token.EncodeOptions
 }
 
 // Option defines the function signature for builder options
@@ -28,6 +42,54 @@ func WithDefaultIDGenerator(generator id.Generator) Option {
 	}
 }
 
+// WithDefaultAudience sets the default audience for all SecEvents created by this builder
+func WithDefaultAudience(audience ...string) Option {
+	return func(b *Builder) {
+		b.defaultAudience = audience
+	}
+}
+
+// WithDefaultSigner sets the signer used by Build and BuildBatch
+func WithDefaultSigner(signer signing.Signer) Option {
+	return func(b *Builder) {
+		b.defaultSigner = signer
+	}
+}
+
+// WithTransactionIDGenerator sets a generator for the txn claim of all SecEvents created by this builder
+func WithTransactionIDGenerator(generator id.Generator) Option {
+	return func(b *Builder) {
+		b.txnGenerator = generator
+	}
+}
+
+// WithClock sets the clock used for the iat claim. Defaults to time.Now.
+func WithClock(clock func() time.Time) Option {
+	return func(b *Builder) {
+		b.clock = clock
+	}
+}
+
+// WithDefaultExtension adds a claim that the token types do not model, e.g. a vendor claim,
+// to all SecEvents created by this builder
+func WithDefaultExtension(name string, value json.RawMessage) Option {
+	return func(b *Builder) {
+		if b.extensions == nil {
+			b.extensions = make(map[string]json.RawMessage)
+		}
+
+		b.extensions[name] = value
+	}
+}
+
+// WithLegacySubjectLayout emits SecEvents in the layout of early CAEP drafts, with the subject
+// inside each event instead of in sub_id, for receivers that still require it
+func WithLegacySubjectLayout() Option {
+	return func(b *Builder) {
+		b.encodeOptions.LegacySubject = true
+	}
+}
+
 // NewBuilder creates a new SecEvent builder with the provided options
 func NewBuilder(opts ...Option) *Builder {
 	b := &Builder{
@@ -53,6 +115,24 @@ func (b *Builder) NewMultiSecEvent() *token.MultiSecEvent {
 		secEvent.WithID(b.defaultIDGenerator.Generate())
 	}
 
+	if len(b.defaultAudience) > 0 {
+		secEvent.WithAudience(b.defaultAudience...)
+	}
+
+	if b.txnGenerator != nil {
+		secEvent.WithTransactionID(b.txnGenerator.Generate())
+	}
+
+	if b.clock != nil {
+		secEvent.IssuedAt = jwt.NewNumericDate(b.clock())
+	}
+
+	for name, value := range b.extensions {
+		secEvent.WithExtension(name, value)
+	}
+
+	secEvent.WithEncodeOptions(b.encodeOptions)
+
 	return secEvent
 }
 
@@ -67,5 +147,78 @@ func (b *Builder) NewSecEvent() *token.SecEvent {
 		secEvent.WithID(b.defaultIDGenerator.Generate())
 	}
 
+	if len(b.defaultAudience) > 0 {
+		secEvent.WithAudience(b.defaultAudience...)
+	}
+
+	if b.txnGenerator != nil {
+		secEvent.WithTransactionID(b.txnGenerator.Generate())
+	}
+
+	if b.clock != nil {
+		secEvent.IssuedAt = jwt.NewNumericDate(b.clock())
+	}
+
+	for name, value := range b.extensions {
+		secEvent.WithExtension(name, value)
+	}
+
+	secEvent.WithEncodeOptions(b.encodeOptions)
+
 	return secEvent
 }
+
+// Build creates a SecEvent for the event and subject using the builder's defaults,
+// validates it, and returns it signed with the default signer
+func (b *Builder) Build(evt event.Event, sub subject.Subject) (string, error) {
+	return b.sign(b.NewSecEvent().WithSubject(sub).WithEvent(evt))
+}
+
+// BatchEntry describes one SecEvent of a BuildBatch call
+type BatchEntry struct {
+	Event   event.Event
+	Subject subject.Subject
+
+	// Audience overrides the builder's default audience when set
+	Audience []string
+}
+
+// BuildBatch builds and signs one SecEvent per entry, e.g. to fan an event out to several
+// receivers or subjects. Each SecEvent gets its own jti. Tokens are returned in entry order;
+// if any entry fails, no tokens are returned.
+func (b *Builder) BuildBatch(entries []BatchEntry) ([]string, error) {
+	tokens := make([]string, 0, len(entries))
+
+	for i, entry := range entries {
+		secEvent := b.NewSecEvent().WithSubject(entry.Subject).WithEvent(entry.Event)
+		if len(entry.Audience) > 0 {
+			secEvent.WithAudience(entry.Audience...)
+		}
+
+		signed, err := b.sign(secEvent)
+		if err != nil {
+			return nil, fmt.Errorf("entry %d: %w", i, err)
+		}
+
+		tokens = append(tokens, signed)
+	}
+
+	return tokens, nil
+}
+
+func (b *Builder) sign(secEvent *token.SecEvent) (string, error) {
+	if b.defaultSigner == nil {
+		return "", fmt.Errorf("no default signer configured")
+	}
+
+	if err := secEvent.Validate(); err != nil {
+		return "", fmt.Errorf("invalid SecEvent: %w", err)
+	}
+
+	signed, err := b.defaultSigner.Sign(secEvent)
+	if err != nil {
+		return "", fmt.Errorf("failed to sign SecEvent: %w", err)
+	}
+
+	return signed, nil
+}
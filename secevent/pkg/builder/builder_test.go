@@ -0,0 +1,183 @@
+package builder_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/builder"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/id"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/parser"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/signing"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+)
+
+func newTestBuilder(t *testing.T, opts ...builder.Option) (*builder.Builder, *parser.Parser) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	signer, err := signing.NewSigner(key, signing.WithKeyID("key-1"))
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+
+	opts = append([]builder.Option{
+		builder.WithDefaultIssuer("https://issuer.example.com"),
+		builder.WithDefaultAudience("https://receiver.example.com"),
+		builder.WithDefaultSigner(signer),
+	}, opts...)
+
+	return builder.NewBuilder(opts...), parser.NewParser(parser.WithPublicKey(&key.PublicKey, "key-1"))
+}
+
+func TestBuilder_Build(t *testing.T) {
+	issuedAt := time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	b, p := newTestBuilder(t,
+		builder.WithTransactionIDGenerator(id.NewSequentialGenerator(id.PrefixTXN, 4)),
+		builder.WithClock(func() time.Time { return issuedAt }),
+	)
+
+	userEmail, _ := subject.NewEmailSubject("user@example.com")
+
+	signed, err := b.Build(caep.NewSessionRevokedEvent(), userEmail)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	set, err := p.ParseSecEvent(signed)
+	if err != nil {
+		t.Fatalf("ParseSecEvent() error = %v", err)
+	}
+
+	if set.Issuer != "https://issuer.example.com" || len(set.Audience) != 1 || set.Audience[0] != "https://receiver.example.com" {
+		t.Errorf("unexpected iss/aud: %s %v", set.Issuer, set.Audience)
+	}
+
+	if set.TransactionID == nil || *set.TransactionID != "txn_0001" {
+		t.Errorf("txn = %v, want txn_0001", set.TransactionID)
+	}
+
+	if !set.IssuedAt.Time.Equal(issuedAt) {
+		t.Errorf("iat = %s, want %s", set.IssuedAt.Time, issuedAt)
+	}
+
+	if _, err := b.Build(caep.NewSessionRevokedEvent(), nil); err == nil {
+		t.Error("expected validation error for missing subject")
+	}
+
+	if _, err := builder.NewBuilder().Build(caep.NewSessionRevokedEvent(), userEmail); err == nil {
+		t.Error("expected error without a default signer")
+	}
+}
+
+func TestBuilder_BuildBatch(t *testing.T) {
+	b, p := newTestBuilder(t)
+
+	alice, _ := subject.NewEmailSubject("alice@example.com")
+	bob, _ := subject.NewEmailSubject("bob@example.com")
+
+	evt := caep.NewSessionRevokedEvent()
+
+	tokens, err := b.BuildBatch([]builder.BatchEntry{
+		{Event: evt, Subject: alice},
+		{Event: evt, Subject: bob, Audience: []string{"https://other-receiver.example.com"}},
+	})
+	if err != nil {
+		t.Fatalf("BuildBatch() error = %v", err)
+	}
+
+	if len(tokens) != 2 {
+		t.Fatalf("got %d tokens, want 2", len(tokens))
+	}
+
+	first, err := p.ParseSecEvent(tokens[0])
+	if err != nil {
+		t.Fatalf("ParseSecEvent() error = %v", err)
+	}
+
+	second, err := p.ParseSecEvent(tokens[1])
+	if err != nil {
+		t.Fatalf("ParseSecEvent() error = %v", err)
+	}
+
+	if first.ID == second.ID {
+		t.Error("batch tokens must have distinct jti values")
+	}
+
+	if second.Audience[0] != "https://other-receiver.example.com" {
+		t.Errorf("audience override not applied: %v", second.Audience)
+	}
+
+	if _, err := b.BuildBatch([]builder.BatchEntry{{Event: evt, Subject: alice}, {Event: evt}}); err == nil {
+		t.Error("expected error for invalid entry")
+	}
+}
+
+func TestBuilder_DefaultExtension(t *testing.T) {
+	b, p := newTestBuilder(t, builder.WithDefaultExtension("toe", json.RawMessage(`1700000000`)))
+
+	userEmail, _ := subject.NewEmailSubject("user@example.com")
+
+	signed, err := b.Build(caep.NewSessionRevokedEvent(), userEmail)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	set, err := p.ParseSecEvent(signed)
+	if err != nil {
+		t.Fatalf("ParseSecEvent() error = %v", err)
+	}
+
+	if got := string(set.Extensions["toe"]); got != "1700000000" {
+		t.Errorf("toe = %q", got)
+	}
+}
+
+func TestBuilder_LegacySubjectLayout(t *testing.T) {
+	b, p := newTestBuilder(t, builder.WithLegacySubjectLayout())
+
+	userEmail, _ := subject.NewEmailSubject("user@example.com")
+
+	signed, err := b.Build(caep.NewSessionRevokedEvent(), userEmail)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.Split(signed, ".")[1])
+	if err != nil {
+		t.Fatalf("failed to decode claims: %v", err)
+	}
+
+	var claims struct {
+		SubjectID json.RawMessage                       `json:"sub_id"`
+		Events    map[string]map[string]json.RawMessage `json:"events"`
+	}
+
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if claims.SubjectID != nil {
+		t.Errorf("sub_id = %s, want it omitted", claims.SubjectID)
+	}
+
+	got := string(claims.Events[string(caep.EventTypeSessionRevoked)]["subject"])
+	if want := `{"email":"user@example.com","subject_type":"email"}`; got != want {
+		t.Errorf("event subject = %s, want %s", got, want)
+	}
+
+	if _, err := p.ParseSecEvent(signed); err == nil {
+		t.Error("ParseSecEvent() accepted a legacy token without WithLegacySubject")
+	}
+}
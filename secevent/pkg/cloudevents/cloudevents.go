@@ -0,0 +1,458 @@
+// Package cloudevents converts SecEvents to and from CloudEvents 1.0 in structured JSON mode.
+//
+// The mapping is:
+//
+//	iss        -> source
+//	jti        -> id
+//	event type -> type
+//	iat        -> time
+//	sub_id     -> subid extension (the sub_id JSON object, as a string)
+//	txn        -> txn extension
+//
+// The event payload is carried as data unless the original token is attached
+// with WithToken, in which case data is the compact token string.
+package cloudevents
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+const (
+	// SpecVersion is the CloudEvents specification version produced by this package
+	SpecVersion = "1.0"
+
+	// ContentTypeJSON is the datacontenttype used when data carries the event payload
+	ContentTypeJSON = "application/json"
+
+	// ContentTypeSecEventJWT is the datacontenttype used when data carries the original token
+	ContentTypeSecEventJWT = "application/secevent+jwt"
+
+	// ExtensionSubjectID holds the sub_id claim as a JSON string
+	ExtensionSubjectID = "subid"
+
+	// ExtensionTransactionID holds the txn claim
+	ExtensionTransactionID = "txn"
+
+	// ExtensionSecEventID holds the jti of the originating token when a MultiSecEvent is
+	// split into several CloudEvents, each of which gets its own id
+	ExtensionSecEventID = "setid"
+)
+
+var extensionNamePattern = regexp.MustCompile(`^[a-z0-9]{1,20}$`)
+
+// reservedAttributes are the context attributes that may not be used as extension names
+var reservedAttributes = map[string]bool{
+	"specversion":     true,
+	"id":              true,
+	"source":          true,
+	"type":            true,
+	"subject":         true,
+	"time":            true,
+	"datacontenttype": true,
+	"dataschema":      true,
+	"data":            true,
+	"data_base64":     true,
+}
+
+// Event is a CloudEvent in structured JSON mode
+type Event struct {
+	SpecVersion     string
+	ID              string
+	Source          string
+	Type            string
+	Subject         string
+	Time            *time.Time
+	DataContentType string
+	DataSchema      string
+	Data            json.RawMessage
+
+	// Extensions holds the extension context attributes, serialized as top-level members
+	Extensions map[string]interface{}
+}
+
+// Validate checks the required context attributes and extension names
+func (e *Event) Validate() error {
+	if e.SpecVersion != SpecVersion {
+		return fmt.Errorf("unsupported specversion %q", e.SpecVersion)
+	}
+
+	if e.ID == "" {
+		return fmt.Errorf("id is required")
+	}
+
+	if e.Source == "" {
+		return fmt.Errorf("source is required")
+	}
+
+	if e.Type == "" {
+		return fmt.Errorf("type is required")
+	}
+
+	for name := range e.Extensions {
+		if reservedAttributes[name] || !extensionNamePattern.MatchString(name) {
+			return fmt.Errorf("invalid extension attribute name %q", name)
+		}
+	}
+
+	return nil
+}
+
+// Extension returns the string value of an extension attribute
+func (e *Event) Extension(name string) (string, bool) {
+	value, ok := e.Extensions[name].(string)
+
+	return value, ok
+}
+
+// SetExtension sets an extension attribute
+func (e *Event) SetExtension(name string, value interface{}) *Event {
+	if e.Extensions == nil {
+		e.Extensions = make(map[string]interface{})
+	}
+
+	e.Extensions[name] = value
+
+	return e
+}
+
+func (e *Event) MarshalJSON() ([]byte, error) {
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	out := make(map[string]interface{}, len(e.Extensions)+9)
+	for name, value := range e.Extensions {
+		out[name] = value
+	}
+
+	out["specversion"] = e.SpecVersion
+	out["id"] = e.ID
+	out["source"] = e.Source
+	out["type"] = e.Type
+
+	if e.Subject != "" {
+		out["subject"] = e.Subject
+	}
+
+	if e.Time != nil {
+		out["time"] = e.Time.UTC().Format(time.RFC3339)
+	}
+
+	if e.DataContentType != "" {
+		out["datacontenttype"] = e.DataContentType
+	}
+
+	if e.DataSchema != "" {
+		out["dataschema"] = e.DataSchema
+	}
+
+	if len(e.Data) > 0 {
+		out["data"] = e.Data
+	}
+
+	return json.Marshal(out)
+}
+
+func (e *Event) UnmarshalJSON(data []byte) error {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return err
+	}
+
+	*e = Event{}
+
+	stringAttributes := map[string]*string{
+		"specversion":     &e.SpecVersion,
+		"id":              &e.ID,
+		"source":          &e.Source,
+		"type":            &e.Type,
+		"subject":         &e.Subject,
+		"datacontenttype": &e.DataContentType,
+		"dataschema":      &e.DataSchema,
+	}
+
+	for name, raw := range members {
+		if target, ok := stringAttributes[name]; ok {
+			if err := json.Unmarshal(raw, target); err != nil {
+				return fmt.Errorf("invalid %s attribute: %w", name, err)
+			}
+
+			continue
+		}
+
+		switch name {
+		case "time":
+			var value string
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return fmt.Errorf("invalid time attribute: %w", err)
+			}
+
+			t, err := time.Parse(time.RFC3339, value)
+			if err != nil {
+				return fmt.Errorf("invalid time attribute: %w", err)
+			}
+
+			e.Time = &t
+		case "data":
+			e.Data = append(json.RawMessage(nil), raw...)
+		case "data_base64":
+			return fmt.Errorf("binary data (data_base64) is not supported")
+		default:
+			var value interface{}
+			if err := json.Unmarshal(raw, &value); err != nil {
+				return fmt.Errorf("invalid %s extension: %w", name, err)
+			}
+
+			e.SetExtension(name, value)
+		}
+	}
+
+	return e.Validate()
+}
+
+type options struct {
+	token    string
+	registry *event.Registry
+}
+
+// Option configures a conversion
+type Option func(*options)
+
+// WithToken carries the original compact token as data instead of the event payload
+func WithToken(rawToken string) Option {
+	return func(o *options) {
+		o.token = rawToken
+	}
+}
+
+// WithEventRegistry sets the registry used to parse event payloads in ToSecEvent.
+// Defaults to event.DefaultRegistry.
+func WithEventRegistry(registry *event.Registry) Option {
+	return func(o *options) {
+		o.registry = registry
+	}
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{registry: event.DefaultRegistry}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return o
+}
+
+// FromSecEvent converts a SecEvent to a CloudEvent
+func FromSecEvent(secEvent *token.SecEvent, opts ...Option) (*Event, error) {
+	if secEvent.Event == nil {
+		return nil, fmt.Errorf("event is required")
+	}
+
+	return newEvent(&secEvent.RegisteredClaims, secEvent.Subject, secEvent.TransactionID, secEvent.ID, secEvent.Event, newOptions(opts))
+}
+
+// FromMultiSecEvent converts a MultiSecEvent to one CloudEvent per event, ordered by event type.
+// A token with a single event keeps its jti as id; otherwise ids are suffixed with the
+// event's index and the jti is carried in the setid extension.
+func FromMultiSecEvent(secEvent *token.MultiSecEvent, opts ...Option) ([]*Event, error) {
+	if len(secEvent.Events) == 0 {
+		return nil, fmt.Errorf("at least one event is required")
+	}
+
+	o := newOptions(opts)
+
+	eventTypes := make([]string, 0, len(secEvent.Events))
+	for eventType := range secEvent.Events {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	sort.Strings(eventTypes)
+
+	events := make([]*Event, 0, len(eventTypes))
+
+	for i, eventType := range eventTypes {
+		id := secEvent.ID
+		if len(eventTypes) > 1 {
+			id = fmt.Sprintf("%s-%d", secEvent.ID, i)
+		}
+
+		ce, err := newEvent(&secEvent.RegisteredClaims, secEvent.Subject, secEvent.TransactionID, id, secEvent.Events[event.EventType(eventType)], o)
+		if err != nil {
+			return nil, fmt.Errorf("event %s: %w", eventType, err)
+		}
+
+		if len(eventTypes) > 1 {
+			ce.SetExtension(ExtensionSecEventID, secEvent.ID)
+		}
+
+		events = append(events, ce)
+	}
+
+	return events, nil
+}
+
+func newEvent(claims *jwt.RegisteredClaims, sub subject.Subject, txn *string, id string, evt event.Event, o *options) (*Event, error) {
+	ce := &Event{
+		SpecVersion: SpecVersion,
+		ID:          id,
+		Source:      claims.Issuer,
+		Type:        string(evt.Type()),
+	}
+
+	if claims.IssuedAt != nil {
+		issuedAt := claims.IssuedAt.Time.UTC()
+		ce.Time = &issuedAt
+	}
+
+	if sub != nil {
+		subjectJSON, err := json.Marshal(sub)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal subject: %w", err)
+		}
+
+		ce.SetExtension(ExtensionSubjectID, string(subjectJSON))
+	}
+
+	if txn != nil {
+		ce.SetExtension(ExtensionTransactionID, *txn)
+	}
+
+	if o.token != "" {
+		data, err := json.Marshal(o.token)
+		if err != nil {
+			return nil, err
+		}
+
+		ce.DataContentType = ContentTypeSecEventJWT
+		ce.Data = data
+	} else {
+		data, err := event.MarshalPayload(evt)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal event payload: %w", err)
+		}
+
+		ce.DataContentType = ContentTypeJSON
+		ce.Data = data
+	}
+
+	if err := ce.Validate(); err != nil {
+		return nil, err
+	}
+
+	return ce, nil
+}
+
+// ToSecEvent converts a CloudEvent back to an unsigned SecEvent that can be passed to a signer.
+// The audience is not part of the mapping and must be set by the caller if required.
+// When data carries a token, the event payload is taken from the token's claims without
+// verifying its signature.
+func ToSecEvent(ce *Event, opts ...Option) (*token.SecEvent, error) {
+	if err := ce.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid CloudEvent: %w", err)
+	}
+
+	o := newOptions(opts)
+
+	payload, err := eventPayload(ce)
+	if err != nil {
+		return nil, err
+	}
+
+	evt, err := o.registry.Parse(event.EventType(ce.Type), payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse event: %w", err)
+	}
+
+	jti := ce.ID
+	if setID, ok := ce.Extension(ExtensionSecEventID); ok && setID != "" {
+		jti = setID
+	}
+
+	secEvent := token.NewSecEvent().
+		WithIssuer(ce.Source).
+		WithID(jti).
+		WithEvent(evt)
+
+	if ce.Time != nil {
+		secEvent.IssuedAt = jwt.NewNumericDate(*ce.Time)
+	}
+
+	if subjectJSON, ok := ce.Extension(ExtensionSubjectID); ok {
+		sub, err := subject.ParseSubject([]byte(subjectJSON))
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse %s extension: %w", ExtensionSubjectID, err)
+		}
+
+		secEvent.WithSubject(sub)
+	}
+
+	if txn, ok := ce.Extension(ExtensionTransactionID); ok {
+		secEvent.WithTransactionID(txn)
+	}
+
+	if err := secEvent.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid SecEvent: %w", err)
+	}
+
+	return secEvent, nil
+}
+
+func eventPayload(ce *Event) (json.RawMessage, error) {
+	switch ce.DataContentType {
+	case "", ContentTypeJSON:
+		if len(bytes.TrimSpace(ce.Data)) == 0 {
+			return nil, fmt.Errorf("data is required")
+		}
+
+		return ce.Data, nil
+	case ContentTypeSecEventJWT:
+		var rawToken string
+		if err := json.Unmarshal(ce.Data, &rawToken); err != nil {
+			return nil, fmt.Errorf("data must be a compact token string: %w", err)
+		}
+
+		return tokenEventPayload(rawToken, event.EventType(ce.Type))
+	default:
+		return nil, fmt.Errorf("unsupported datacontenttype %q", ce.DataContentType)
+	}
+}
+
+// tokenEventPayload extracts the payload of one event from an unverified JWS
+func tokenEventPayload(rawToken string, eventType event.EventType) (json.RawMessage, error) {
+	segments := strings.Split(rawToken, ".")
+	if len(segments) != 3 {
+		return nil, fmt.Errorf("data must be a signed (JWS) compact token")
+	}
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(segments[1])
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token claims: %w", err)
+	}
+
+	var claims struct {
+		Events map[event.EventType]json.RawMessage `json:"events"`
+	}
+
+	if err := json.Unmarshal(claimsJSON, &claims); err != nil {
+		return nil, fmt.Errorf("failed to parse token claims: %w", err)
+	}
+
+	payload, ok := claims.Events[eventType]
+	if !ok {
+		return nil, fmt.Errorf("token does not contain an event of type %s", eventType)
+	}
+
+	return payload, nil
+}
@@ -0,0 +1,271 @@
+package cloudevents_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/cloudevents"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/parser"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/signing"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+var issuedAt = time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)
+
+func newTestSecEvent(t *testing.T) *token.SecEvent {
+	t.Helper()
+
+	userEmail, err := subject.NewEmailSubject("user@example.com")
+	if err != nil {
+		t.Fatalf("NewEmailSubject() error = %v", err)
+	}
+
+	secEvent := token.NewSecEvent().
+		WithIssuer("https://issuer.example.com").
+		WithID("jti-1").
+		WithAudience("https://receiver.example.com").
+		WithSubject(userEmail).
+		WithTransactionID("txn-1").
+		WithEvent(caep.NewSessionRevokedEvent().
+			WithEventTimestamp(1700000000).
+			WithReasonAdmin("en", "Policy violation"))
+	secEvent.IssuedAt = jwt.NewNumericDate(issuedAt)
+
+	return secEvent
+}
+
+func TestFromSecEvent(t *testing.T) {
+	ce, err := cloudevents.FromSecEvent(newTestSecEvent(t))
+	if err != nil {
+		t.Fatalf("FromSecEvent() error = %v", err)
+	}
+
+	data, err := json.Marshal(ce)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got map[string]interface{}
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want := map[string]interface{}{
+		"specversion":     "1.0",
+		"id":              "jti-1",
+		"source":          "https://issuer.example.com",
+		"type":            string(caep.EventTypeSessionRevoked),
+		"time":            "2025-01-02T03:04:05Z",
+		"datacontenttype": "application/json",
+		"subid":           `{"email":"user@example.com","format":"email"}`,
+		"txn":             "txn-1",
+	}
+
+	for name, value := range want {
+		if got[name] != value {
+			t.Errorf("%s = %v, want %v", name, got[name], value)
+		}
+	}
+
+	payload, ok := got["data"].(map[string]interface{})
+	if !ok || payload["event_timestamp"] != float64(1700000000) {
+		t.Errorf("unexpected data %v", got["data"])
+	}
+}
+
+func TestRoundTrip(t *testing.T) {
+	original := newTestSecEvent(t)
+
+	ce, err := cloudevents.FromSecEvent(original)
+	if err != nil {
+		t.Fatalf("FromSecEvent() error = %v", err)
+	}
+
+	data, err := json.Marshal(ce)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var decoded cloudevents.Event
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	secEvent, err := cloudevents.ToSecEvent(&decoded)
+	if err != nil {
+		t.Fatalf("ToSecEvent() error = %v", err)
+	}
+
+	if secEvent.Issuer != original.Issuer || secEvent.ID != original.ID {
+		t.Errorf("iss/jti = %s/%s, want %s/%s", secEvent.Issuer, secEvent.ID, original.Issuer, original.ID)
+	}
+
+	if !secEvent.IssuedAt.Time.Equal(issuedAt) {
+		t.Errorf("iat = %v, want %v", secEvent.IssuedAt.Time, issuedAt)
+	}
+
+	if secEvent.TransactionID == nil || *secEvent.TransactionID != "txn-1" {
+		t.Errorf("unexpected txn %v", secEvent.TransactionID)
+	}
+
+	if !subject.Equal(secEvent.Subject, original.Subject) {
+		t.Errorf("subject = %v, want %v", secEvent.Subject, original.Subject)
+	}
+
+	revoked, ok := secEvent.Event.(*caep.SessionRevokedEvent)
+	if !ok {
+		t.Fatalf("event = %T, want *caep.SessionRevokedEvent", secEvent.Event)
+	}
+
+	if reason, _ := revoked.GetReasonAdmin("en"); reason != "Policy violation" {
+		t.Errorf("reason_admin = %q", reason)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	signer, err := signing.NewSigner(key, signing.WithKeyID("key-1"))
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+
+	signed, err := signer.Sign(secEvent)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	if _, err := parser.NewParser(parser.WithPublicKey(&key.PublicKey, "key-1")).ParseSecEvent(signed); err != nil {
+		t.Errorf("ParseSecEvent() error = %v", err)
+	}
+}
+
+func TestWithToken(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	signer, err := signing.NewSigner(key)
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+
+	original := newTestSecEvent(t)
+
+	signed, err := signer.Sign(original)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	ce, err := cloudevents.FromSecEvent(original, cloudevents.WithToken(signed))
+	if err != nil {
+		t.Fatalf("FromSecEvent() error = %v", err)
+	}
+
+	if ce.DataContentType != cloudevents.ContentTypeSecEventJWT {
+		t.Errorf("datacontenttype = %q", ce.DataContentType)
+	}
+
+	var carried string
+	if err := json.Unmarshal(ce.Data, &carried); err != nil || carried != signed {
+		t.Errorf("data does not carry the original token: %s", ce.Data)
+	}
+
+	secEvent, err := cloudevents.ToSecEvent(ce)
+	if err != nil {
+		t.Fatalf("ToSecEvent() error = %v", err)
+	}
+
+	if secEvent.Event.Type() != caep.EventTypeSessionRevoked {
+		t.Errorf("unexpected event type %s", secEvent.Event.Type())
+	}
+}
+
+func TestFromMultiSecEvent(t *testing.T) {
+	userEmail, _ := subject.NewEmailSubject("user@example.com")
+
+	multi := token.NewMultiSecEvent().
+		WithIssuer("https://issuer.example.com").
+		WithID("jti-1").
+		WithSubject(userEmail).
+		WithEvent(caep.NewSessionRevokedEvent()).
+		WithEvent(caep.NewTokenClaimsChangeEvent().WithClaim("role", "admin"))
+
+	events, err := cloudevents.FromMultiSecEvent(multi)
+	if err != nil {
+		t.Fatalf("FromMultiSecEvent() error = %v", err)
+	}
+
+	if len(events) != 2 {
+		t.Fatalf("got %d events, want 2", len(events))
+	}
+
+	for i, ce := range events {
+		if setID, _ := ce.Extension(cloudevents.ExtensionSecEventID); setID != "jti-1" {
+			t.Errorf("events[%d] setid = %q", i, setID)
+		}
+
+		secEvent, err := cloudevents.ToSecEvent(ce)
+		if err != nil {
+			t.Fatalf("ToSecEvent() error = %v", err)
+		}
+
+		if secEvent.ID != "jti-1" || string(secEvent.Event.Type()) != ce.Type {
+			t.Errorf("events[%d] round trip = %s/%s", i, secEvent.ID, secEvent.Event.Type())
+		}
+	}
+
+	if events[0].ID == events[1].ID {
+		t.Errorf("split events share id %s", events[0].ID)
+	}
+}
+
+func TestEvent_UnmarshalJSONInvalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{name: "missing id", data: `{"specversion":"1.0","source":"s","type":"t"}`},
+		{name: "wrong specversion", data: `{"specversion":"0.3","id":"1","source":"s","type":"t"}`},
+		{name: "invalid extension name", data: `{"specversion":"1.0","id":"1","source":"s","type":"t","sub_id":"x"}`},
+		{name: "binary data", data: `{"specversion":"1.0","id":"1","source":"s","type":"t","data_base64":"AA=="}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ce cloudevents.Event
+			if err := json.Unmarshal([]byte(tt.data), &ce); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestRoundTrip_EventExtensions(t *testing.T) {
+	original := newTestSecEvent(t)
+	original.Event.(*caep.SessionRevokedEvent).SetExtension("x_vendor_session", json.RawMessage(`"s-42"`))
+
+	ce, err := cloudevents.FromSecEvent(original)
+	if err != nil {
+		t.Fatalf("FromSecEvent() error = %v", err)
+	}
+
+	secEvent, err := cloudevents.ToSecEvent(ce)
+	if err != nil {
+		t.Fatalf("ToSecEvent() error = %v", err)
+	}
+
+	revoked := secEvent.Event.(*caep.SessionRevokedEvent)
+	if got := string(revoked.GetExtensions()["x_vendor_session"]); got != `"s-42"` {
+		t.Errorf("x_vendor_session = %s, want \"s-42\"", got)
+	}
+}
@@ -2,7 +2,6 @@ package event
 
 import (
 	"encoding/json"
-	"fmt"
 )
 
 type EventType string
@@ -28,6 +27,10 @@ type Event interface {
 type BaseEvent struct {
 	eventType EventType
 	payload   interface{}
+
+	// Extensions holds payload members the event type does not model, e.g. vendor fields.
+	// They are filled when the event is parsed and written back when it is marshaled.
+	Extensions map[string]json.RawMessage `json:"-"`
 }
 
 func (e *BaseEvent) Type() EventType {
@@ -42,84 +45,60 @@ func (e *BaseEvent) Payload() interface{} {
 	return e.payload
 }
 
+// GetExtensions returns the payload members the event type does not model
+func (e *BaseEvent) GetExtensions() map[string]json.RawMessage {
+	return e.Extensions
+}
+
+// SetExtension sets a payload member the event type does not model
+func (e *BaseEvent) SetExtension(name string, value json.RawMessage) {
+	if e.Extensions == nil {
+		e.Extensions = make(map[string]json.RawMessage)
+	}
+
+	e.Extensions[name] = value
+}
+
 func (e *BaseEvent) MarshalJSON() ([]byte, error) {
-	return json.Marshal(e.payload)
+	data, err := json.Marshal(e.payload)
+	if err != nil {
+		return nil, err
+	}
+
+	return MergeExtensions(data, e.Extensions)
 }
 
 func (e *BaseEvent) UnmarshalJSON(data []byte) error {
 	return json.Unmarshal(data, &e.payload)
 }
 
-// EventParserRegistry is a map of event types to their respective parsers
+// EventParserRegistry is a map of event types to their respective parsers.
+//
+// Deprecated: it backs DefaultRegistry; accessing it directly is not safe for concurrent
+// use. Use DefaultRegistry or a Registry of your own instead.
 var EventParserRegistry = map[EventType]func([]byte) (Event, error){}
 
-// RegisterEventParser registers an event type and its parser
+// RegisterEventParser registers an event type and its parser with DefaultRegistry
 func RegisterEventParser(eventType EventType, parser func([]byte) (Event, error)) {
-	EventParserRegistry[eventType] = parser
+	DefaultRegistry.Register(eventType, parser)
 }
 
-// GetEventParser returns the parser for a given event type
+// GetEventParser returns the parser for a given event type from DefaultRegistry
 func GetEventParser(eventType EventType) (func([]byte) (Event, error), bool) {
-	parser, ok := EventParserRegistry[eventType]
-
-	return parser, ok
+	return DefaultRegistry.Lookup(eventType)
 }
 
-// ParseEvent parses event data based on the registered event type
+// ParseEvent parses event data based on the event types registered with DefaultRegistry
 func ParseEvent(eventType EventType, data []byte) (Event, error) {
-	parser, ok := GetEventParser(eventType)
-	if !ok {
-		return nil, NewError(
-			ErrCodeInvalidEventType,
-			fmt.Sprintf("no parser registered for event type: %s", eventType),
-			"event_type",
-			"",
-		)
-	}
-
-	// Validate that the data is valid JSON
-	if !json.Valid(data) {
-		return nil, NewError(
-			ErrCodeParseError,
-			"invalid JSON data",
-			"",
-			"",
-		)
-	}
-
-	// Use the registered parser to parse the event
-	event, err := parser(data)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse event: %w", err)
-	}
-
-	// Verify the event type matches
-	if event.Type() != eventType {
-		return nil, NewError(
-			ErrCodeInvalidEventType,
-			fmt.Sprintf("parsed event type %s does not match expected type %s",
-				event.Type(), eventType),
-			"event_type",
-			"",
-		)
-	}
-
-	return event, nil
+	return DefaultRegistry.Parse(eventType, data)
 }
 
-// GetRegisteredEventTypes returns all registered event types
+// GetRegisteredEventTypes returns all event types registered with DefaultRegistry
 func GetRegisteredEventTypes() []EventType {
-	types := make([]EventType, 0, len(EventParserRegistry))
-	for eventType := range EventParserRegistry {
-		types = append(types, eventType)
-	}
-
-	return types
+	return DefaultRegistry.Types()
 }
 
-// IsEventTypeRegistered checks if a parser is registered for the given event type
+// IsEventTypeRegistered checks if a parser is registered with DefaultRegistry for the given event type
 func IsEventTypeRegistered(eventType EventType) bool {
-	_, ok := EventParserRegistry[eventType]
-
-	return ok
+	return DefaultRegistry.IsRegistered(eventType)
 }
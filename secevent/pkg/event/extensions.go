@@ -0,0 +1,144 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Extensible is implemented by events that keep payload members their type does not model,
+// such as vendor-specific fields. Every event embedding BaseEvent implements it.
+type Extensible interface {
+	// GetExtensions returns the unmodeled payload members by name
+	GetExtensions() map[string]json.RawMessage
+
+	// SetExtension sets an unmodeled payload member
+	SetExtension(name string, value json.RawMessage)
+}
+
+// MarshalPayload marshals the payload of an event together with its extensions.
+// Members modeled by the event take precedence over extensions of the same name.
+func MarshalPayload(evt Event) ([]byte, error) {
+	data, err := json.Marshal(evt.Payload())
+	if err != nil {
+		return nil, err
+	}
+
+	if extensible, ok := evt.(Extensible); ok {
+		return MergeExtensions(data, extensible.GetExtensions())
+	}
+
+	return data, nil
+}
+
+// MergeExtensions adds extension members to a marshaled JSON object. Members already present
+// in data take precedence. data is returned unchanged when there are no extensions.
+func MergeExtensions(data []byte, extensions map[string]json.RawMessage) ([]byte, error) {
+	if len(extensions) == 0 {
+		return data, nil
+	}
+
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, fmt.Errorf("extensions require a JSON object: %w", err)
+	}
+
+	if members == nil {
+		members = make(map[string]json.RawMessage, len(extensions))
+	}
+
+	for name, value := range extensions {
+		if _, ok := members[name]; !ok {
+			members[name] = value
+		}
+	}
+
+	return json.Marshal(members)
+}
+
+// UnknownMembers returns the members of a JSON object whose names are not known, or nil if
+// there are none
+func UnknownMembers(data []byte, known func(name string) bool) (map[string]json.RawMessage, error) {
+	var members map[string]json.RawMessage
+	if err := json.Unmarshal(data, &members); err != nil {
+		return nil, err
+	}
+
+	var unknown map[string]json.RawMessage
+
+	for name, value := range members {
+		if known(name) {
+			continue
+		}
+
+		if unknown == nil {
+			unknown = make(map[string]json.RawMessage)
+		}
+
+		unknown[name] = value
+	}
+
+	return unknown, nil
+}
+
+// modelsMember reports whether encoding/json would decode the member into one of the modeled
+// fields: an exact name match, or failing that a case-insensitive one
+func modelsMember(modeled map[string]json.RawMessage, name string) bool {
+	if _, ok := modeled[name]; ok {
+		return true
+	}
+
+	for field := range modeled {
+		if strings.EqualFold(field, name) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ExtractExtensions records the members of data that the decoded event does not model,
+// replacing any extensions it already holds. The modeled members are those present when the
+// event's payload is marshaled. Event types call it from UnmarshalJSON once their fields are
+// set, so that unknown members survive every decode path.
+func ExtractExtensions(evt Event, data []byte) error {
+	extensible, ok := evt.(Extensible)
+	if !ok {
+		return nil
+	}
+
+	extensions := extensible.GetExtensions()
+	for name := range extensions {
+		delete(extensions, name)
+	}
+
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' {
+		return nil
+	}
+
+	payload, err := json.Marshal(evt.Payload())
+	if err != nil {
+		return err
+	}
+
+	var modeled map[string]json.RawMessage
+	if err := json.Unmarshal(payload, &modeled); err != nil {
+		// Payloads that are not JSON objects cannot carry extensions
+		return nil
+	}
+
+	unknown, err := UnknownMembers(trimmed, func(name string) bool {
+		return modelsMember(modeled, name)
+	})
+	if err != nil {
+		return err
+	}
+
+	for name, value := range unknown {
+		extensible.SetExtension(name, value)
+	}
+
+	return nil
+}
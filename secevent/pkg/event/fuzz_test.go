@@ -0,0 +1,36 @@
+package event_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/risc"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/ssf"
+)
+
+func FuzzParseEvent(f *testing.F) {
+	f.Add(string(caep.EventTypeSessionRevoked), []byte(`{"event_timestamp":1700000000,"initiating_entity":"admin","reason_admin":{"en":"x"}}`))
+	f.Add(string(caep.EventTypeCredentialChange), []byte(`{"credential_type":"password","change_type":"update"}`))
+	f.Add(string(caep.EventTypeTokenClaimsChange), []byte(`{"claims":{"role":"admin"}}`))
+	f.Add(string(caep.EventTypeAssuranceLevelChange), []byte(`{"namespace":"NIST-AAL","current_level":"nist-aal2"}`))
+	f.Add(string(risc.EventTypeAccountDisabled), []byte(`{"reason":"hijacking"}`))
+	f.Add(string(ssf.EventTypeVerification), []byte(`{"state":"abc"}`))
+	f.Add("https://vendor.example.com/event-type/widget", []byte(`{"nested":{"a":[1,2,3]}}`))
+
+	f.Fuzz(func(t *testing.T, eventType string, data []byte) {
+		evt, err := event.ParseEventLenient(event.EventType(eventType), data)
+		if err != nil {
+			return
+		}
+
+		if evt.Type() != event.EventType(eventType) {
+			t.Errorf("Type() = %s, want %s", evt.Type(), eventType)
+		}
+
+		if _, err := json.Marshal(evt.Payload()); err != nil {
+			t.Errorf("failed to marshal parsed event: %v", err)
+		}
+	})
+}
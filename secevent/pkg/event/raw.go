@@ -0,0 +1,104 @@
+package event
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// RawEvent holds an event whose type has no registered parser. It keeps the original JSON so
+// that intermediaries can forward events they do not understand without altering them.
+//
+// MarshalJSON and Raw return the original bytes. When a RawEvent is marshaled as part of a
+// SecEvent, or by anything else that goes through encoding/json (including signing), the
+// payload is compacted and HTML-escaped: the result equals json.Compact followed by
+// json.HTMLEscape of the original bytes. Member order, number literals and other string
+// escapes are kept, so the payload remains equivalent JSON.
+type RawEvent struct {
+	eventType EventType
+	raw       json.RawMessage
+}
+
+// NewRawEvent creates a RawEvent of the given type from its JSON payload
+func NewRawEvent(eventType EventType, data []byte) (*RawEvent, error) {
+	e := &RawEvent{eventType: eventType}
+
+	if err := e.UnmarshalJSON(data); err != nil {
+		return nil, err
+	}
+
+	if err := e.Validate(); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Type returns the event type URI
+func (e *RawEvent) Type() EventType {
+	return e.eventType
+}
+
+// Validate checks that the event has a type and its payload is a JSON object
+func (e *RawEvent) Validate() error {
+	if e.eventType == "" {
+		return NewError(ErrCodeMissingValue, "event type is required", "event_type", "")
+	}
+
+	return validateRawPayload(e.raw)
+}
+
+// validateRawPayload checks that data is a JSON object
+func validateRawPayload(data []byte) error {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 || trimmed[0] != '{' || !json.Valid(trimmed) {
+		return NewError(ErrCodeInvalidFormat, "event payload must be a JSON object", "", "")
+	}
+
+	return nil
+}
+
+// Payload returns the original JSON payload
+func (e *RawEvent) Payload() interface{} {
+	return e.Raw()
+}
+
+// Raw returns a copy of the original JSON payload
+func (e *RawEvent) Raw() json.RawMessage {
+	return append(json.RawMessage(nil), e.raw...)
+}
+
+// Decode unmarshals the original JSON payload into v
+func (e *RawEvent) Decode(v interface{}) error {
+	return json.Unmarshal(e.raw, v)
+}
+
+// MarshalJSON returns the original JSON payload unchanged
+func (e *RawEvent) MarshalJSON() ([]byte, error) {
+	if e.raw == nil {
+		return []byte("{}"), nil
+	}
+
+	return e.Raw(), nil
+}
+
+// UnmarshalJSON stores a copy of data as the event payload. The payload must be a JSON object;
+// the event type is not part of the payload and is left unchanged.
+func (e *RawEvent) UnmarshalJSON(data []byte) error {
+	if !json.Valid(data) {
+		return NewError(ErrCodeParseError, "invalid JSON data", "", "")
+	}
+
+	if err := validateRawPayload(data); err != nil {
+		return err
+	}
+
+	e.raw = append(json.RawMessage(nil), data...)
+
+	return nil
+}
+
+// ParseEventLenient parses event data like ParseEvent, but returns a RawEvent instead of an
+// error when no parser is registered with DefaultRegistry for the event type
+func ParseEventLenient(eventType EventType, data []byte) (Event, error) {
+	return DefaultRegistry.ParseLenient(eventType, data)
+}
@@ -0,0 +1,69 @@
+package event
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRawEvent(t *testing.T) {
+	data := []byte(`{ "b": 2,  "a": "x" }`)
+
+	evt, err := NewRawEvent("https://example.com/event", data)
+	if err != nil {
+		t.Fatalf("NewRawEvent() error = %v", err)
+	}
+
+	// The caller's buffer is copied
+	data[3] = 'z'
+
+	marshaled, err := evt.MarshalJSON()
+	if err != nil {
+		t.Fatalf("MarshalJSON() error = %v", err)
+	}
+
+	if string(marshaled) != `{ "b": 2,  "a": "x" }` {
+		t.Errorf("MarshalJSON() = %s", marshaled)
+	}
+
+	if _, err := NewRawEvent("https://example.com/event", []byte(`[1,2]`)); err == nil {
+		t.Error("expected error for non-object payload")
+	}
+
+	if _, err := NewRawEvent("", []byte(`{}`)); err == nil {
+		t.Error("expected error for missing event type")
+	}
+
+	if _, ok := evt.Payload().(json.RawMessage); !ok {
+		t.Errorf("Payload() returned %T", evt.Payload())
+	}
+}
+
+func TestParseEventLenient(t *testing.T) {
+	evt, err := ParseEventLenient("https://example.com/unregistered", []byte(`{"k":"v"}`))
+	if err != nil {
+		t.Fatalf("ParseEventLenient() error = %v", err)
+	}
+
+	if _, ok := evt.(*RawEvent); !ok {
+		t.Errorf("ParseEventLenient() returned %T, want *RawEvent", evt)
+	}
+
+	if _, err := ParseEvent("https://example.com/unregistered", []byte(`{"k":"v"}`)); err == nil {
+		t.Error("expected ParseEvent to reject unregistered type")
+	}
+}
+
+func TestRawEvent_UnmarshalZeroValue(t *testing.T) {
+	var evt RawEvent
+	if err := json.Unmarshal([]byte(`{"k": "v"}`), &evt); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if string(evt.Raw()) != `{"k": "v"}` {
+		t.Errorf("Raw() = %s", evt.Raw())
+	}
+
+	if err := json.Unmarshal([]byte(`[1]`), &evt); err == nil {
+		t.Error("expected error for non-object payload")
+	}
+}
@@ -0,0 +1,146 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Registry maps event types to their parsers. It is safe for concurrent use.
+type Registry struct {
+	mu      sync.RWMutex
+	parsers map[EventType]func([]byte) (Event, error)
+}
+
+// DefaultRegistry is the registry used by the package-level functions. The built-in
+// schemes register their events with it when imported.
+var DefaultRegistry = &Registry{parsers: EventParserRegistry}
+
+// NewRegistry creates an empty registry
+func NewRegistry() *Registry {
+	return &Registry{parsers: make(map[EventType]func([]byte) (Event, error))}
+}
+
+// Clone returns a new registry with the same parsers, which can then be modified
+// without affecting r
+func (r *Registry) Clone() *Registry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	clone := NewRegistry()
+	for eventType, parser := range r.parsers {
+		clone.parsers[eventType] = parser
+	}
+
+	return clone
+}
+
+// Register registers an event type and its parser, replacing any existing parser
+func (r *Registry) Register(eventType EventType, parser func([]byte) (Event, error)) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.parsers[eventType] = parser
+}
+
+// Unregister removes the parser for an event type
+func (r *Registry) Unregister(eventType EventType) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	delete(r.parsers, eventType)
+}
+
+// Lookup returns the parser for a given event type
+func (r *Registry) Lookup(eventType EventType) (func([]byte) (Event, error), bool) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	parser, ok := r.parsers[eventType]
+
+	return parser, ok
+}
+
+// IsRegistered checks if a parser is registered for the given event type
+func (r *Registry) IsRegistered(eventType EventType) bool {
+	_, ok := r.Lookup(eventType)
+
+	return ok
+}
+
+// Types returns all registered event types in sorted order
+func (r *Registry) Types() []EventType {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	types := make([]EventType, 0, len(r.parsers))
+	for eventType := range r.parsers {
+		types = append(types, eventType)
+	}
+
+	sort.Slice(types, func(i, j int) bool { return types[i] < types[j] })
+
+	return types
+}
+
+// Parse parses event data using the parser registered for the event type
+func (r *Registry) Parse(eventType EventType, data []byte) (Event, error) {
+	parser, ok := r.Lookup(eventType)
+	if !ok {
+		return nil, NewError(
+			ErrCodeInvalidEventType,
+			fmt.Sprintf("no parser registered for event type: %s", eventType),
+			"event_type",
+			"",
+		)
+	}
+
+	// Validate that the data is valid JSON
+	if !json.Valid(data) {
+		return nil, NewError(
+			ErrCodeParseError,
+			"invalid JSON data",
+			"",
+			"",
+		)
+	}
+
+	// Use the registered parser to parse the event
+	event, err := parser(data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse event: %w", err)
+	}
+
+	// Verify the event type matches
+	if event.Type() != eventType {
+		return nil, NewError(
+			ErrCodeInvalidEventType,
+			fmt.Sprintf("parsed event type %s does not match expected type %s",
+				event.Type(), eventType),
+			"event_type",
+			"",
+		)
+	}
+
+	if err := ExtractExtensions(event, data); err != nil {
+		return nil, fmt.Errorf("failed to parse event extensions: %w", err)
+	}
+
+	return event, nil
+}
+
+// ParseLenient parses event data like Parse, but returns a RawEvent instead of an
+// error when no parser is registered for the event type
+func (r *Registry) ParseLenient(eventType EventType, data []byte) (Event, error) {
+	if r.IsRegistered(eventType) {
+		return r.Parse(eventType, data)
+	}
+
+	rawEvent, err := NewRawEvent(eventType, data)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse event: %w", err)
+	}
+
+	return rawEvent, nil
+}
@@ -0,0 +1,98 @@
+package event
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+)
+
+type testEvent struct {
+	BaseEvent
+}
+
+func parseTestEvent(eventType EventType) func([]byte) (Event, error) {
+	return func(data []byte) (Event, error) {
+		var payload map[string]interface{}
+		if err := json.Unmarshal(data, &payload); err != nil {
+			return nil, err
+		}
+
+		evt := &testEvent{}
+		evt.SetType(eventType)
+
+		return evt, nil
+	}
+}
+
+func (e *testEvent) Validate() error { return nil }
+
+func TestRegistry_Isolation(t *testing.T) {
+	const eventType EventType = "https://example.com/registry-isolation"
+
+	r := NewRegistry()
+	r.Register(eventType, parseTestEvent(eventType))
+
+	if _, err := r.Parse(eventType, []byte(`{}`)); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	if IsEventTypeRegistered(eventType) {
+		t.Error("registering with a new registry must not affect DefaultRegistry")
+	}
+
+	clone := r.Clone()
+	clone.Unregister(eventType)
+
+	if !r.IsRegistered(eventType) || clone.IsRegistered(eventType) {
+		t.Error("Clone() must be independent of the original")
+	}
+
+	if _, err := clone.Parse(eventType, []byte(`{}`)); err == nil {
+		t.Error("expected error for unregistered event type")
+	}
+}
+
+func TestRegistry_DefaultIsBackwardCompatible(t *testing.T) {
+	const eventType EventType = "https://example.com/registry-default"
+
+	RegisterEventParser(eventType, parseTestEvent(eventType))
+	defer DefaultRegistry.Unregister(eventType)
+
+	if _, ok := EventParserRegistry[eventType]; !ok {
+		t.Error("RegisterEventParser must still populate EventParserRegistry")
+	}
+
+	if _, err := ParseEvent(eventType, []byte(`{}`)); err != nil {
+		t.Errorf("ParseEvent() error = %v", err)
+	}
+}
+
+func TestRegistry_ConcurrentUse(t *testing.T) {
+	r := NewRegistry()
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 20; i++ {
+		eventType := EventType(fmt.Sprintf("https://example.com/concurrent/%d", i))
+
+		wg.Add(2)
+
+		go func() {
+			defer wg.Done()
+			r.Register(eventType, parseTestEvent(eventType))
+		}()
+
+		go func() {
+			defer wg.Done()
+			_, _ = r.Parse(eventType, []byte(`{}`))
+			_ = r.Types()
+		}()
+	}
+
+	wg.Wait()
+
+	if n := len(r.Types()); n != 20 {
+		t.Errorf("len(Types()) = %d, want 20", n)
+	}
+}
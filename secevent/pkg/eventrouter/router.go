@@ -0,0 +1,154 @@
+// Package eventrouter dispatches the events of parsed SecEvents to typed handlers.
+package eventrouter
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"sort"
+	"sync"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+// ErrNoHandler is returned when an event has no handler and no fallback is configured
+var ErrNoHandler = errors.New("no handler registered for event type")
+
+// HandlerFunc handles a single event. The event is available as secEvent.Event.
+type HandlerFunc func(ctx context.Context, secEvent *token.SecEvent) error
+
+// Middleware wraps a HandlerFunc, for example to add logging, metrics or recovery
+type Middleware func(next HandlerFunc) HandlerFunc
+
+// Router dispatches events to the handler registered for their type.
+// It is safe for concurrent use.
+type Router struct {
+	mu         sync.RWMutex
+	handlers   map[event.EventType]HandlerFunc
+	fallback   HandlerFunc
+	middleware []Middleware
+}
+
+// New creates an empty router
+func New() *Router {
+	return &Router{
+		handlers: make(map[event.EventType]HandlerFunc),
+	}
+}
+
+// On registers a typed handler for an event type. The parsed event is asserted to E
+// before the handler is called; a mismatch (for example an *event.RawEvent produced by
+// lenient parsing) is returned as an error.
+//
+//	eventrouter.On(router, caep.EventTypeSessionRevoked,
+//		func(ctx context.Context, set *token.SecEvent, evt *caep.SessionRevokedEvent) error {
+//			return revokeSessions(ctx, set.Subject)
+//		})
+func On[E event.Event](r *Router, eventType event.EventType, handler func(ctx context.Context, secEvent *token.SecEvent, evt E) error) {
+	r.Handle(eventType, func(ctx context.Context, secEvent *token.SecEvent) error {
+		evt, ok := secEvent.Event.(E)
+		if !ok {
+			var want E
+
+			return fmt.Errorf("event of type %s is %T, handler expects %T", eventType, secEvent.Event, want)
+		}
+
+		return handler(ctx, secEvent, evt)
+	})
+}
+
+// Handle registers an untyped handler for an event type, replacing any existing handler
+func (r *Router) Handle(eventType event.EventType, handler HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.handlers[eventType] = handler
+}
+
+// Fallback sets the handler for events whose type has no registered handler
+func (r *Router) Fallback(handler HandlerFunc) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.fallback = handler
+}
+
+// Use appends middleware. Middleware wraps every handler, including the fallback;
+// the first middleware added is the outermost.
+func (r *Router) Use(middleware ...Middleware) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.middleware = append(r.middleware, middleware...)
+}
+
+// Dispatch calls the handler for the SecEvent's event
+func (r *Router) Dispatch(ctx context.Context, secEvent *token.SecEvent) error {
+	if secEvent == nil || secEvent.Event == nil {
+		return fmt.Errorf("SecEvent has no event")
+	}
+
+	handler, err := r.handlerFor(secEvent.Event.Type())
+	if err != nil {
+		return err
+	}
+
+	return handler(ctx, secEvent)
+}
+
+// DispatchMulti calls the handler for each event of a MultiSecEvent in order of event type.
+// Each handler receives a SecEvent carrying the shared claims and that one event. All events
+// are dispatched even if a handler fails; the errors are joined.
+func (r *Router) DispatchMulti(ctx context.Context, multiSecEvent *token.MultiSecEvent) error {
+	if multiSecEvent == nil || len(multiSecEvent.Events) == 0 {
+		return fmt.Errorf("SecEvent has no events")
+	}
+
+	eventTypes := make([]event.EventType, 0, len(multiSecEvent.Events))
+	for eventType := range multiSecEvent.Events {
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	sort.Slice(eventTypes, func(i, j int) bool { return eventTypes[i] < eventTypes[j] })
+
+	var errs []error
+
+	for _, eventType := range eventTypes {
+		secEvent := &token.SecEvent{
+			RegisteredClaims: multiSecEvent.RegisteredClaims,
+			Event:            multiSecEvent.Events[eventType],
+			Subject:          multiSecEvent.Subject,
+			TransactionID:    multiSecEvent.TransactionID,
+			Extensions:       maps.Clone(multiSecEvent.Extensions),
+		}
+
+		if err := r.Dispatch(ctx, secEvent); err != nil {
+			errs = append(errs, fmt.Errorf("event %s: %w", eventType, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// handlerFor returns the middleware-wrapped handler for an event type
+func (r *Router) handlerFor(eventType event.EventType) (HandlerFunc, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+
+	handler, ok := r.handlers[eventType]
+	if !ok {
+		handler = r.fallback
+	}
+
+	if handler == nil {
+		return nil, fmt.Errorf("%w: %s", ErrNoHandler, eventType)
+	}
+
+	for i := len(r.middleware) - 1; i >= 0; i-- {
+		handler = r.middleware[i](handler)
+	}
+
+	return handler, nil
+}
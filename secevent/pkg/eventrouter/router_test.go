@@ -0,0 +1,183 @@
+package eventrouter
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/ssf"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+func newTestSubject(t *testing.T) subject.Subject {
+	t.Helper()
+
+	s, err := subject.NewEmailSubject("user@example.com")
+	if err != nil {
+		t.Fatalf("NewEmailSubject() error = %v", err)
+	}
+
+	return s
+}
+
+func TestRouter_TypedDispatch(t *testing.T) {
+	router := New()
+
+	var got *caep.SessionRevokedEvent
+
+	On(router, caep.EventTypeSessionRevoked, func(_ context.Context, set *token.SecEvent, evt *caep.SessionRevokedEvent) error {
+		if set.ID != "jti-1" {
+			t.Errorf("unexpected jti %s", set.ID)
+		}
+
+		got = evt
+
+		return nil
+	})
+
+	evt := caep.NewSessionRevokedEvent()
+	secEvent := token.NewSecEvent().WithID("jti-1").WithSubject(newTestSubject(t)).WithEvent(evt)
+
+	if err := router.Dispatch(context.Background(), secEvent); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	if got != evt {
+		t.Error("handler did not receive the typed event")
+	}
+
+	// A handler typed for a different event is reported, not silently skipped
+	On(router, caep.EventTypeSessionRevoked, func(context.Context, *token.SecEvent, *caep.SessionEstablishedEvent) error {
+		return nil
+	})
+
+	if err := router.Dispatch(context.Background(), secEvent); err == nil {
+		t.Error("expected type mismatch error")
+	}
+}
+
+func TestRouter_FallbackAndNoHandler(t *testing.T) {
+	router := New()
+	secEvent := token.NewSecEvent().WithSubject(newTestSubject(t)).WithEvent(caep.NewSessionRevokedEvent())
+
+	if err := router.Dispatch(context.Background(), secEvent); !errors.Is(err, ErrNoHandler) {
+		t.Fatalf("expected ErrNoHandler, got %v", err)
+	}
+
+	var fallbackType event.EventType
+
+	router.Fallback(func(_ context.Context, set *token.SecEvent) error {
+		fallbackType = set.Event.Type()
+
+		return nil
+	})
+
+	if err := router.Dispatch(context.Background(), secEvent); err != nil {
+		t.Fatalf("Dispatch() error = %v", err)
+	}
+
+	if fallbackType != caep.EventTypeSessionRevoked {
+		t.Errorf("fallback received %s", fallbackType)
+	}
+}
+
+func TestRouter_MultiDispatchOrderAndMiddleware(t *testing.T) {
+	router := New()
+
+	var calls []string
+
+	record := func(name string) Middleware {
+		return func(next HandlerFunc) HandlerFunc {
+			return func(ctx context.Context, set *token.SecEvent) error {
+				calls = append(calls, name+">")
+				err := next(ctx, set)
+				calls = append(calls, "<"+name)
+
+				return err
+			}
+		}
+	}
+
+	router.Use(record("outer"), record("inner"))
+
+	On(router, caep.EventTypeSessionRevoked, func(context.Context, *token.SecEvent, *caep.SessionRevokedEvent) error {
+		calls = append(calls, "session-revoked")
+
+		return nil
+	})
+
+	On(router, caep.EventTypeSessionEstablished, func(context.Context, *token.SecEvent, *caep.SessionEstablishedEvent) error {
+		calls = append(calls, "session-established")
+
+		return errors.New("downstream unavailable")
+	})
+
+	On(router, ssf.EventTypeVerification, func(context.Context, *token.SecEvent, *ssf.VerificationEvent) error {
+		calls = append(calls, "verification")
+
+		return nil
+	})
+
+	multi := token.NewMultiSecEvent().
+		WithSubject(newTestSubject(t)).
+		WithEvent(ssf.NewVerificationEvent()).
+		WithEvent(caep.NewSessionRevokedEvent()).
+		WithEvent(caep.NewSessionEstablishedEvent())
+
+	err := router.DispatchMulti(context.Background(), multi)
+	if err == nil || !strings.Contains(err.Error(), "downstream unavailable") {
+		t.Fatalf("expected joined handler error, got %v", err)
+	}
+
+	want := []string{
+		"outer>", "inner>", "session-established", "<inner", "<outer",
+		"outer>", "inner>", "session-revoked", "<inner", "<outer",
+		"outer>", "inner>", "verification", "<inner", "<outer",
+	}
+
+	if strings.Join(calls, ",") != strings.Join(want, ",") {
+		t.Errorf("calls = %v\nwant    %v", calls, want)
+	}
+}
+
+func TestRouter_MultiDispatchExtensions(t *testing.T) {
+	router := New()
+
+	var got []string
+
+	On(router, caep.EventTypeSessionRevoked, func(_ context.Context, set *token.SecEvent, _ *caep.SessionRevokedEvent) error {
+		got = append(got, string(set.Extensions["toe"]))
+		set.WithExtension("toe", json.RawMessage(`0`))
+
+		return nil
+	})
+
+	On(router, ssf.EventTypeVerification, func(_ context.Context, set *token.SecEvent, _ *ssf.VerificationEvent) error {
+		got = append(got, string(set.Extensions["toe"]))
+
+		return nil
+	})
+
+	multi := token.NewMultiSecEvent().
+		WithSubject(newTestSubject(t)).
+		WithEvent(caep.NewSessionRevokedEvent()).
+		WithEvent(ssf.NewVerificationEvent()).
+		WithExtension("toe", json.RawMessage(`1700000000`))
+
+	if err := router.DispatchMulti(context.Background(), multi); err != nil {
+		t.Fatalf("DispatchMulti() error = %v", err)
+	}
+
+	if strings.Join(got, ",") != "1700000000,1700000000" {
+		t.Errorf("handlers saw toe = %v, want 1700000000 for each event", got)
+	}
+
+	if string(multi.Extensions["toe"]) != "1700000000" {
+		t.Errorf("handler modified the MultiSecEvent: toe = %s", multi.Extensions["toe"])
+	}
+}
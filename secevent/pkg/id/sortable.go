@@ -0,0 +1,241 @@
+package id
+
+import (
+	"crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// The generators in this file produce IDs whose lexical order matches generation order:
+// IDs from one generator are strictly increasing, including within a millisecond and if
+// the system clock steps backwards.
+
+// crockfordAlphabet is the Crockford base32 alphabet used by ULIDs
+const crockfordAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+// ULIDGenerator generates ULIDs: a 48-bit millisecond timestamp followed by 80 random bits,
+// encoded as 26 Crockford base32 characters. Within a millisecond the random part is
+// incremented, as described by the ULID monotonicity specification.
+type ULIDGenerator struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	lastMS  uint64
+	entropy [10]byte
+}
+
+func NewULIDGenerator() *ULIDGenerator {
+	return &ULIDGenerator{now: time.Now}
+}
+
+func (g *ULIDGenerator) Generate() string {
+	ms := unixMilli(g.now())
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if ms > g.lastMS {
+		g.lastMS = ms
+		readRandom(g.entropy[:])
+	} else if !incrementBytes(g.entropy[:]) {
+		// Random part exhausted within this millisecond; borrow the next one
+		g.lastMS++
+		readRandom(g.entropy[:])
+	}
+
+	var b [16]byte
+
+	binary.BigEndian.PutUint16(b[0:2], uint16(g.lastMS>>32))
+	binary.BigEndian.PutUint32(b[2:6], uint32(g.lastMS))
+	copy(b[6:], g.entropy[:])
+
+	return encodeCrockford(b)
+}
+
+// UUIDv7Generator generates version 7 UUIDs (RFC 9562): a 48-bit millisecond timestamp,
+// a 12-bit counter that orders UUIDs within a millisecond, and 62 random bits
+type UUIDv7Generator struct {
+	now func() time.Time
+
+	mu      sync.Mutex
+	lastMS  uint64
+	counter uint16
+}
+
+func NewUUIDv7Generator() *UUIDv7Generator {
+	return &UUIDv7Generator{now: time.Now}
+}
+
+func (g *UUIDv7Generator) Generate() string {
+	ms := unixMilli(g.now())
+
+	g.mu.Lock()
+
+	if ms > g.lastMS {
+		g.lastMS = ms
+		g.counter = randomCounterSeed()
+	} else if g.counter < 0x0fff {
+		g.counter++
+	} else {
+		g.lastMS++
+		g.counter = randomCounterSeed()
+	}
+
+	ms, counter := g.lastMS, g.counter
+
+	g.mu.Unlock()
+
+	var u uuid.UUID
+
+	binary.BigEndian.PutUint16(u[0:2], uint16(ms>>32))
+	binary.BigEndian.PutUint32(u[2:6], uint32(ms))
+	binary.BigEndian.PutUint16(u[6:8], 0x7000|counter)
+	readRandom(u[8:])
+	u[8] = u[8]&0x3f | 0x80 // RFC 9562 variant
+
+	return u.String()
+}
+
+// DefaultSnowflakeEpoch is the epoch of SnowflakeGenerator timestamps
+var DefaultSnowflakeEpoch = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+const (
+	snowflakeWorkerBits   = 10
+	snowflakeSequenceBits = 12
+
+	// MaxSnowflakeWorkerID is the largest worker ID accepted by NewSnowflakeGenerator
+	MaxSnowflakeWorkerID = 1<<snowflakeWorkerBits - 1
+
+	maxSnowflakeSequence = 1<<snowflakeSequenceBits - 1
+)
+
+// SnowflakeGenerator generates Snowflake-style IDs: 41 bits of milliseconds since the epoch,
+// a 10-bit worker ID and a 12-bit sequence. IDs are formatted as zero-padded 19-digit decimals
+// so that their lexical order matches their numeric order. Each concurrently running
+// generator must use a distinct worker ID.
+type SnowflakeGenerator struct {
+	workerID int64
+	epoch    time.Time
+	now      func() time.Time
+
+	mu       sync.Mutex
+	lastMS   int64
+	sequence int64
+}
+
+func NewSnowflakeGenerator(workerID int64) (*SnowflakeGenerator, error) {
+	if workerID < 0 || workerID > MaxSnowflakeWorkerID {
+		return nil, fmt.Errorf("worker ID must be between 0 and %d", MaxSnowflakeWorkerID)
+	}
+
+	return &SnowflakeGenerator{
+		workerID: workerID,
+		epoch:    DefaultSnowflakeEpoch,
+		now:      time.Now,
+		lastMS:   -1,
+	}, nil
+}
+
+// WithEpoch sets the epoch timestamps are measured from. All generators producing IDs
+// for the same consumers must use the same epoch.
+func (g *SnowflakeGenerator) WithEpoch(epoch time.Time) *SnowflakeGenerator {
+	g.epoch = epoch
+
+	return g
+}
+
+func (g *SnowflakeGenerator) Generate() string {
+	return fmt.Sprintf("%019d", g.GenerateInt64())
+}
+
+// GenerateInt64 returns the next ID as an integer
+func (g *SnowflakeGenerator) GenerateInt64() int64 {
+	ms := g.now().Sub(g.epoch).Milliseconds()
+	if ms < 0 {
+		ms = 0
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	if ms > g.lastMS {
+		g.lastMS = ms
+		g.sequence = 0
+	} else if g.sequence < maxSnowflakeSequence {
+		g.sequence++
+	} else {
+		g.lastMS++
+		g.sequence = 0
+	}
+
+	return g.lastMS<<(snowflakeWorkerBits+snowflakeSequenceBits) |
+		g.workerID<<snowflakeSequenceBits |
+		g.sequence
+}
+
+func unixMilli(t time.Time) uint64 {
+	ms := t.UnixMilli()
+	if ms < 0 {
+		return 0
+	}
+
+	return uint64(ms) & (1<<48 - 1)
+}
+
+func readRandom(b []byte) {
+	if _, err := rand.Read(b); err != nil {
+		panic(fmt.Sprintf("id: failed to read random bytes: %v", err))
+	}
+}
+
+// randomCounterSeed returns a random 12-bit counter start with its top bit clear, leaving
+// at least 2048 increments before the counter overflows
+func randomCounterSeed() uint16 {
+	var b [2]byte
+	readRandom(b[:])
+
+	return binary.BigEndian.Uint16(b[:]) & 0x07ff
+}
+
+// incrementBytes adds one to a big-endian number, reporting false on overflow
+func incrementBytes(b []byte) bool {
+	for i := len(b) - 1; i >= 0; i-- {
+		b[i]++
+		if b[i] != 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+func encodeCrockford(b [16]byte) string {
+	hi := binary.BigEndian.Uint64(b[0:8])
+	lo := binary.BigEndian.Uint64(b[8:16])
+
+	var out [26]byte
+
+	// 26 characters carry 130 bits; the first character holds only the top 3 bits
+	for i := range out {
+		shift := uint(125 - 5*i)
+
+		var v uint64
+
+		switch {
+		case shift >= 64:
+			v = hi >> (shift - 64)
+		case shift == 0:
+			v = lo
+		default:
+			v = lo>>shift | hi<<(64-shift)
+		}
+
+		out[i] = crockfordAlphabet[v&0x1f]
+	}
+
+	return string(out[:])
+}
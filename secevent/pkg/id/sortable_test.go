@@ -0,0 +1,173 @@
+package id
+
+import (
+	"regexp"
+	"sort"
+	"sync"
+	"testing"
+	"testing/quick"
+	"time"
+)
+
+// steppedClock returns a clock that advances by the given millisecond steps, which may be
+// zero (several IDs in one millisecond) or negative (the clock stepping backwards)
+func steppedClock(steps []int8) func() time.Time {
+	var (
+		mu sync.Mutex
+		t  = time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC)
+		i  int
+	)
+
+	return func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+
+		if i < len(steps) {
+			t = t.Add(time.Duration(steps[i]%4) * time.Millisecond)
+			i++
+		}
+
+		return t
+	}
+}
+
+func sortableGenerators(now func() time.Time) map[string]Generator {
+	ulid := NewULIDGenerator()
+	ulid.now = now
+
+	uuidV7 := NewUUIDv7Generator()
+	uuidV7.now = now
+
+	snowflake, _ := NewSnowflakeGenerator(7)
+	snowflake.now = now
+
+	return map[string]Generator{"ulid": ulid, "uuidv7": uuidV7, "snowflake": snowflake}
+}
+
+func TestSortableGenerators_OrderAndUniqueness(t *testing.T) {
+	for _, name := range []string{"ulid", "uuidv7", "snowflake"} {
+		t.Run(name, func(t *testing.T) {
+			property := func(steps []int8) bool {
+				generator := sortableGenerators(steppedClock(steps))[name]
+
+				previous := ""
+				for range append(steps, 0, 0, 0) {
+					id := generator.Generate()
+					if id <= previous {
+						t.Logf("%q generated after %q", id, previous)
+
+						return false
+					}
+
+					previous = id
+				}
+
+				return true
+			}
+
+			if err := quick.Check(property, &quick.Config{MaxCount: 200}); err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestSortableGenerators_Concurrent(t *testing.T) {
+	const (
+		goroutines = 8
+		perRoutine = 2000
+	)
+
+	// A frozen clock forces every ID into the same millisecond
+	frozen := func() time.Time { return time.Date(2025, 6, 1, 0, 0, 0, 0, time.UTC) }
+
+	for name, generator := range sortableGenerators(frozen) {
+		t.Run(name, func(t *testing.T) {
+			results := make([][]string, goroutines)
+
+			var wg sync.WaitGroup
+
+			for g := 0; g < goroutines; g++ {
+				wg.Add(1)
+
+				go func(g int) {
+					defer wg.Done()
+
+					for i := 0; i < perRoutine; i++ {
+						results[g] = append(results[g], generator.Generate())
+					}
+				}(g)
+			}
+
+			wg.Wait()
+
+			seen := make(map[string]bool, goroutines*perRoutine)
+
+			for _, ids := range results {
+				if !sort.StringsAreSorted(ids) {
+					t.Error("IDs generated by one goroutine are not increasing")
+				}
+
+				for _, id := range ids {
+					if seen[id] {
+						t.Fatalf("duplicate ID %s", id)
+					}
+
+					seen[id] = true
+				}
+			}
+		})
+	}
+}
+
+func TestULIDGenerator_Format(t *testing.T) {
+	g := NewULIDGenerator()
+	g.now = func() time.Time { return time.UnixMilli(1469918176385) }
+
+	id := g.Generate()
+
+	// Timestamp from the ULID specification's example
+	if len(id) != 26 || id[:10] != "01ARYZ6S41" {
+		t.Errorf("Generate() = %s, want 26 characters starting with 01ARYZ6S41", id)
+	}
+
+	if !regexp.MustCompile(`^[0-9A-HJKMNP-TV-Z]{26}$`).MatchString(id) {
+		t.Errorf("Generate() = %s contains non-Crockford characters", id)
+	}
+}
+
+func TestUUIDv7Generator_Format(t *testing.T) {
+	id := NewUUIDv7Generator().Generate()
+
+	if !regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-7[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`).MatchString(id) {
+		t.Errorf("Generate() = %s is not a version 7 UUID", id)
+	}
+}
+
+func TestSnowflakeGenerator(t *testing.T) {
+	if _, err := NewSnowflakeGenerator(MaxSnowflakeWorkerID + 1); err == nil {
+		t.Error("expected error for out of range worker ID")
+	}
+
+	g, err := NewSnowflakeGenerator(5)
+	if err != nil {
+		t.Fatalf("NewSnowflakeGenerator() error = %v", err)
+	}
+
+	epoch := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	g.WithEpoch(epoch).now = func() time.Time { return epoch.Add(3 * time.Millisecond) }
+
+	first, second := g.GenerateInt64(), g.GenerateInt64()
+
+	if first>>22 != 3 || (first>>12)&MaxSnowflakeWorkerID != 5 || first&maxSnowflakeSequence != 0 {
+		t.Errorf("unexpected layout of %d", first)
+	}
+
+	if second != first+1 {
+		t.Errorf("second ID %d, want %d", second, first+1)
+	}
+
+	if len(g.Generate()) != 19 {
+		t.Error("Generate() must return 19 digits")
+	}
+}
@@ -0,0 +1,413 @@
+package ocsf
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/risc"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+// ErrUnsupportedEvent is returned for event types without an OCSF mapping
+var ErrUnsupportedEvent = errors.New("event type has no OCSF mapping")
+
+// DefaultProductName is the metadata.product.name used when WithProduct is not set
+const DefaultProductName = "Shared Signals Framework"
+
+type mapping struct {
+	classUID     int
+	activityID   int
+	activityName string
+	severityID   int
+
+	// entityType is the managed entity type of Entity Management records
+	entityType string
+}
+
+var mappings = map[event.EventType]mapping{
+	caep.EventTypeSessionEstablished: {classUID: ClassAuthentication, activityID: ActivityLogon, activityName: "Logon"},
+	caep.EventTypeSessionRevoked:     {classUID: ClassAuthentication, activityID: ActivityLogoff, activityName: "Logoff"},
+	caep.EventTypeSessionPresented:   {classUID: ClassAuthentication, activityID: ActivityOther, activityName: "Session Presented"},
+	risc.EventTypeSessionsRevoked:    {classUID: ClassAuthentication, activityID: ActivityLogoff, activityName: "Logoff"},
+
+	caep.EventTypeCredentialChange:                {classUID: ClassAccountChange, activityID: ActivityOther, activityName: "Credential Change"},
+	risc.EventTypeAccountCredentialChangeRequired: {classUID: ClassAccountChange, activityID: ActivityAccountPasswordReset, activityName: "Password Reset", severityID: SeverityMedium},
+	risc.EventTypeAccountDisabled:                 {classUID: ClassAccountChange, activityID: ActivityAccountDisable, activityName: "Disable"},
+	risc.EventTypeAccountEnabled:                  {classUID: ClassAccountChange, activityID: ActivityAccountEnable, activityName: "Enable"},
+	risc.EventTypeAccountPurged:                   {classUID: ClassAccountChange, activityID: ActivityAccountDelete, activityName: "Delete"},
+	risc.EventTypeCredentialCompromise:            {classUID: ClassAccountChange, activityID: ActivityOther, activityName: "Credential Compromise", severityID: SeverityHigh},
+	risc.EventTypeRecoveryActivated:               {classUID: ClassAccountChange, activityID: ActivityOther, activityName: "Recovery Activated"},
+	risc.EventTypeRecoveryInformationChanged:      {classUID: ClassAccountChange, activityID: ActivityOther, activityName: "Recovery Information Changed"},
+
+	caep.EventTypeAssuranceLevelChange:   {classUID: ClassEntityManagement, activityID: ActivityEntityUpdate, activityName: "Update", entityType: "Assurance Level"},
+	caep.EventTypeDeviceComplianceChange: {classUID: ClassEntityManagement, activityID: ActivityEntityUpdate, activityName: "Update", entityType: "Device"},
+	caep.EventTypeTokenClaimsChange:      {classUID: ClassEntityManagement, activityID: ActivityEntityUpdate, activityName: "Update", entityType: "Token"},
+	caep.EventTypeRiskLevelChange:        {classUID: ClassEntityManagement, activityID: ActivityEntityUpdate, activityName: "Update", entityType: "Risk Level"},
+	risc.EventTypeIdentifierChanged:      {classUID: ClassEntityManagement, activityID: ActivityEntityUpdate, activityName: "Update", entityType: "Identifier"},
+	risc.EventTypeIdentifierRecycled:     {classUID: ClassEntityManagement, activityID: ActivityEntityUpdate, activityName: "Update", entityType: "Identifier"},
+}
+
+// IsSupported reports whether an event type has an OCSF mapping
+func IsSupported(eventType event.EventType) bool {
+	_, ok := mappings[eventType]
+
+	return ok
+}
+
+// Exporter converts SecEvents to OCSF records
+type Exporter struct {
+	product  *Product
+	language string
+}
+
+// Option defines the function signature for exporter options
+type Option func(*Exporter)
+
+// WithProduct sets metadata.product. By default the product name is DefaultProductName
+// and the vendor is the issuer of the SecEvent.
+func WithProduct(name, vendorName string) Option {
+	return func(x *Exporter) {
+		x.product = &Product{Name: name, VendorName: vendorName}
+	}
+}
+
+// WithLanguage sets the language of the reason used as the record message. Defaults to "en".
+func WithLanguage(language string) Option {
+	return func(x *Exporter) {
+		x.language = language
+	}
+}
+
+// NewExporter creates a new OCSF exporter with the provided options
+func NewExporter(opts ...Option) *Exporter {
+	x := &Exporter{
+		language: "en",
+	}
+
+	for _, opt := range opts {
+		opt(x)
+	}
+
+	return x
+}
+
+// Export converts a SecEvent to an OCSF record
+func (x *Exporter) Export(secEvent *token.SecEvent) (*Record, error) {
+	if secEvent.Event == nil {
+		return nil, fmt.Errorf("event is required")
+	}
+
+	return x.export(&secEvent.RegisteredClaims, secEvent.Subject, secEvent.TransactionID, secEvent.Event)
+}
+
+// ExportMulti converts each event of a MultiSecEvent to an OCSF record, ordered by event type
+func (x *Exporter) ExportMulti(secEvent *token.MultiSecEvent) ([]*Record, error) {
+	eventTypes := make([]string, 0, len(secEvent.Events))
+	for eventType := range secEvent.Events {
+		eventTypes = append(eventTypes, string(eventType))
+	}
+
+	sort.Strings(eventTypes)
+
+	records := make([]*Record, 0, len(eventTypes))
+
+	for _, eventType := range eventTypes {
+		record, err := x.export(&secEvent.RegisteredClaims, secEvent.Subject, secEvent.TransactionID, secEvent.Events[event.EventType(eventType)])
+		if err != nil {
+			return nil, err
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+func (x *Exporter) export(claims *jwt.RegisteredClaims, sub subject.Subject, txn *string, evt event.Event) (*Record, error) {
+	m, ok := mappings[evt.Type()]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnsupportedEvent, evt.Type())
+	}
+
+	m = refine(m, evt)
+
+	if m.severityID == 0 {
+		m.severityID = SeverityInformational
+	}
+
+	record := &Record{
+		ActivityID:   m.activityID,
+		ActivityName: m.activityName,
+		CategoryUID:  CategoryIdentityAccessManagement,
+		CategoryName: "Identity & Access Management",
+		ClassUID:     m.classUID,
+		ClassName:    classNames[m.classUID],
+		TypeUID:      m.classUID*100 + m.activityID,
+		TypeName:     classNames[m.classUID] + ": " + m.activityName,
+		SeverityID:   m.severityID,
+		Severity:     severityNames[m.severityID],
+		Metadata: Metadata{
+			Version: Version,
+			Product: x.productFor(claims.Issuer),
+			UID:     claims.ID,
+		},
+		Unmapped: map[string]interface{}{
+			"event_type": string(evt.Type()),
+		},
+	}
+
+	if txn != nil {
+		record.Metadata.CorrelationUID = *txn
+	}
+
+	if claims.IssuedAt != nil {
+		record.Metadata.LoggedTime = claims.IssuedAt.UnixMilli()
+		record.Time = record.Metadata.LoggedTime
+	}
+
+	if metadata := caepMetadata(evt); metadata != nil {
+		x.applyMetadata(record, metadata)
+	}
+
+	if sub != nil {
+		if err := applySubject(record, sub); err != nil {
+			return nil, err
+		}
+	}
+
+	data, err := eventData(evt)
+	if err != nil {
+		return nil, err
+	}
+
+	if m.classUID == ClassEntityManagement {
+		record.Entity = &ManagedEntity{
+			Type: m.entityType,
+			Data: data,
+		}
+
+		if record.Device != nil && m.entityType == "Device" {
+			record.Entity.UID = record.Device.UID
+		}
+	} else if len(data) > 0 {
+		record.Unmapped["event"] = data
+	}
+
+	return record, nil
+}
+
+// eventData returns the event payload without the CAEP metadata members, which are
+// mapped separately
+func eventData(evt event.Event) (map[string]interface{}, error) {
+	payload, err := event.MarshalPayload(evt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal event payload: %w", err)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal(payload, &data); err != nil {
+		return nil, fmt.Errorf("event payload must be a JSON object: %w", err)
+	}
+
+	for _, member := range []string{"event_timestamp", "initiating_entity", "reason_admin", "reason_user"} {
+		delete(data, member)
+	}
+
+	return data, nil
+}
+
+// refine picks a more specific activity or severity from the event payload
+func refine(m mapping, evt event.Event) mapping {
+	switch e := evt.(type) {
+	case *caep.CredentialChangeEvent:
+		password := e.GetCredentialType() == caep.CredentialTypePassword
+
+		switch e.GetChangeType() {
+		case caep.ChangeTypeCreate, caep.ChangeTypeUpdate:
+			if password {
+				m.activityID, m.activityName = ActivityAccountPasswordChange, "Password Change"
+			} else if e.GetChangeType() == caep.ChangeTypeCreate {
+				m.activityID, m.activityName = ActivityAccountMFAEnable, "MFA Factor Enable"
+			}
+		case caep.ChangeTypeRevoke, caep.ChangeTypeDelete:
+			if !password {
+				m.activityID, m.activityName = ActivityAccountMFADisable, "MFA Factor Disable"
+			}
+		}
+	case *risc.AccountDisabledEvent:
+		if reason, ok := e.GetReason(); ok && reason == risc.AccountDisabledReasonHijacking {
+			m.severityID = SeverityHigh
+		}
+	}
+
+	return m
+}
+
+func (x *Exporter) productFor(issuer string) Product {
+	if x.product != nil {
+		return *x.product
+	}
+
+	return Product{Name: DefaultProductName, VendorName: issuer}
+}
+
+// caepMetadata returns the CAEP metadata of an event. RISC credential-compromise events
+// carry the same members in their payload.
+func caepMetadata(evt event.Event) *caep.EventMetadata {
+	switch e := evt.(type) {
+	case interface{ GetMetadata() *caep.EventMetadata }:
+		return e.GetMetadata()
+	case *risc.CredentialCompromiseEvent:
+		return &caep.EventMetadata{
+			EventTimestamp: e.EventTimestamp,
+			ReasonAdmin:    e.ReasonAdmin,
+			ReasonUser:     e.ReasonUser,
+		}
+	}
+
+	return nil
+}
+
+func (x *Exporter) applyMetadata(record *Record, metadata *caep.EventMetadata) {
+	if timestamp, ok := metadata.GetEventTimestamp(); ok {
+		record.Time = timestamp * 1000
+	}
+
+	if entity, ok := metadata.GetInitiatingEntity(); ok {
+		record.Unmapped["initiating_entity"] = entity.String()
+	}
+
+	if reasons := metadata.GetAllReasonAdmin(); len(reasons) > 0 {
+		record.Unmapped["reason_admin"] = reasons
+	}
+
+	if reasons := metadata.GetAllReasonUser(); len(reasons) > 0 {
+		record.Unmapped["reason_user"] = reasons
+	}
+
+	record.Message = x.reason(metadata.GetAllReasonAdmin())
+	if record.Message == "" {
+		record.Message = x.reason(metadata.GetAllReasonUser())
+	}
+}
+
+// reason returns the reason in the configured language, falling back to the first language in
+// sorted order so that output is deterministic
+func (x *Exporter) reason(reasons map[string]string) string {
+	if reason, ok := reasons[x.language]; ok {
+		return reason
+	}
+
+	languages := make([]string, 0, len(reasons))
+	for language := range reasons {
+		languages = append(languages, language)
+	}
+
+	if len(languages) == 0 {
+		return ""
+	}
+
+	sort.Strings(languages)
+
+	return reasons[languages[0]]
+}
+
+func applySubject(record *Record, sub subject.Subject) error {
+	subjectJSON, err := json.Marshal(sub)
+	if err != nil {
+		return fmt.Errorf("failed to marshal subject: %w", err)
+	}
+
+	record.Unmapped["sub_id"] = json.RawMessage(subjectJSON)
+
+	complexSubject, ok := sub.(subject.ComplexSubject)
+	if !ok {
+		record.User = userFor(sub)
+
+		return nil
+	}
+
+	if user, ok := complexSubject.UserComponent(); ok {
+		record.User = userFor(user)
+	}
+
+	if device, ok := complexSubject.DeviceComponent(); ok {
+		if uid := identifier(device); uid != "" {
+			record.Device = &Device{UID: uid}
+		}
+	}
+
+	if session, ok := complexSubject.SessionComponent(); ok {
+		if uid := identifier(session); uid != "" {
+			record.Session = &Session{UID: uid}
+		}
+	}
+
+	return nil
+}
+
+// userFor maps a subject identifier to an OCSF user. Aliases contribute every identifier
+// that fills a field not already set.
+func userFor(sub subject.Subject) *User {
+	user := &User{}
+
+	identifiers := []subject.Subject{sub}
+	if aliases, ok := sub.(*subject.AliasesSubject); ok {
+		identifiers = aliases.Identifiers()
+	}
+
+	for _, id := range identifiers {
+		switch s := id.(type) {
+		case *subject.EmailSubject:
+			if user.EmailAddr == "" {
+				user.EmailAddr = s.Email()
+			}
+		case *subject.AccountSubject:
+			if user.Name == "" {
+				user.Name = s.URI()
+			}
+		default:
+			if user.UID == "" {
+				user.UID = identifier(id)
+			}
+		}
+	}
+
+	if *user == (User{}) {
+		return nil
+	}
+
+	return user
+}
+
+// identifier returns the value that identifies a simple subject
+func identifier(sub subject.Subject) string {
+	switch s := sub.(type) {
+	case *subject.EmailSubject:
+		return s.Email()
+	case *subject.PhoneSubject:
+		return s.Phone()
+	case *subject.IssuerSubSubject:
+		return s.Sub()
+	case *subject.OpaqueSubject:
+		return s.ID()
+	case *subject.AccountSubject:
+		return s.URI()
+	case *subject.URISubject:
+		return s.URI()
+	case *subject.DIDSubject:
+		return s.URL()
+	case *subject.JWTIDSubject:
+		return s.JWTID()
+	case *subject.SAMLIDSubject:
+		return s.AssertionID()
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,190 @@
+package ocsf_test
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/ocsf"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/risc"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/ssf"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+var update = flag.Bool("update", false, "update golden files")
+
+func newSecEvent(t *testing.T, sub subject.Subject, evt event.Event) *token.SecEvent {
+	t.Helper()
+
+	secEvent := token.NewSecEvent().
+		WithIssuer("https://issuer.example.com").
+		WithID("jti-1").
+		WithTransactionID("txn-1").
+		WithSubject(sub).
+		WithEvent(evt)
+	secEvent.IssuedAt = jwt.NewNumericDate(time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC))
+
+	return secEvent
+}
+
+func TestExporter_Golden(t *testing.T) {
+	userEmail, _ := subject.NewEmailSubject("user@example.com")
+	issSub, _ := subject.NewIssuerSubSubject("https://idp.example.com", "user-123")
+	device, _ := subject.NewOpaqueSubject("device-42")
+	aliases, _ := subject.NewAliasesSubject(userEmail, issSub)
+
+	tests := []struct {
+		name     string
+		secEvent *token.SecEvent
+	}{
+		{
+			name: "session_revoked",
+			secEvent: newSecEvent(t, userEmail, caep.NewSessionRevokedEvent().
+				WithEventTimestamp(1700000000).
+				WithInitiatingEntity(caep.InitiatingEntityAdmin).
+				WithReasonAdmin("en", "Landspeed Policy Violation: C076E82F").
+				WithReasonAdmin("de", "Landspeed-Richtlinienverstoss: C076E82F").
+				WithReasonUser("en", "Access attempt from multiple regions.")),
+		},
+		{
+			name: "credential_change",
+			secEvent: newSecEvent(t, issSub, caep.NewCredentialChangeEvent(caep.CredentialTypePassword, caep.ChangeTypeUpdate).
+				WithInitiatingEntity(caep.InitiatingEntityUser).
+				WithReasonUser("en", "Password changed by user")),
+		},
+		{
+			name: "fido2_credential_revoked",
+			secEvent: newSecEvent(t, issSub, caep.NewCredentialChangeEvent(caep.CredentialTypeFIDO2Roaming, caep.ChangeTypeRevoke).
+				WithFriendlyName("Security key")),
+		},
+		{
+			name: "credential_compromise",
+			secEvent: newSecEvent(t, userEmail, risc.NewCredentialCompromiseEvent(caep.CredentialTypePassword).
+				WithEventTimestamp(1700000000).
+				WithReasonAdmin("en", "Password found in breach corpus")),
+		},
+		{
+			name:     "account_disabled",
+			secEvent: newSecEvent(t, aliases, risc.NewAccountDisabledEvent().WithReason(risc.AccountDisabledReasonHijacking)),
+		},
+		{
+			name: "device_compliance_change",
+			secEvent: newSecEvent(t, subject.NewComplexSubject().WithUser(userEmail).WithDevice(device),
+				caep.NewDeviceComplianceChangeEvent(caep.ComplianceStatusNotCompliant, caep.ComplianceStatusCompliant).
+					WithEventTimestamp(1700000000).
+					WithInitiatingEntity(caep.InitiatingEntityPolicy).
+					WithReasonAdmin("en", "Disk encryption disabled")),
+		},
+	}
+
+	exporter := ocsf.NewExporter(ocsf.WithProduct("Example IdP", "Example Inc."))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			record, err := exporter.Export(tt.secEvent)
+			if err != nil {
+				t.Fatalf("Export() error = %v", err)
+			}
+
+			got, err := json.MarshalIndent(record, "", "  ")
+			if err != nil {
+				t.Fatalf("MarshalIndent() error = %v", err)
+			}
+
+			got = append(got, '\n')
+
+			golden := filepath.Join("testdata", tt.name+".json")
+
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatalf("failed to update golden file: %v", err)
+				}
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("failed to read golden file: %v", err)
+			}
+
+			if string(got) != string(want) {
+				t.Errorf("record does not match %s\ngot:\n%s\nwant:\n%s", golden, got, want)
+			}
+		})
+	}
+}
+
+func TestExporter_ExportMulti(t *testing.T) {
+	userEmail, _ := subject.NewEmailSubject("user@example.com")
+
+	multi := token.NewMultiSecEvent().
+		WithIssuer("https://issuer.example.com").
+		WithID("jti-1").
+		WithSubject(userEmail).
+		WithEvent(caep.NewSessionRevokedEvent()).
+		WithEvent(risc.NewAccountDisabledEvent())
+
+	records, err := ocsf.NewExporter().ExportMulti(multi)
+	if err != nil {
+		t.Fatalf("ExportMulti() error = %v", err)
+	}
+
+	if len(records) != 2 {
+		t.Fatalf("got %d records, want 2", len(records))
+	}
+
+	if records[0].ClassUID != ocsf.ClassAuthentication || records[1].ClassUID != ocsf.ClassAccountChange {
+		t.Errorf("unexpected classes %d, %d", records[0].ClassUID, records[1].ClassUID)
+	}
+
+	if records[0].Metadata.Product.VendorName != "https://issuer.example.com" {
+		t.Errorf("default vendor = %q", records[0].Metadata.Product.VendorName)
+	}
+}
+
+func TestExporter_Unsupported(t *testing.T) {
+	userEmail, _ := subject.NewEmailSubject("user@example.com")
+
+	_, err := ocsf.NewExporter().Export(newSecEvent(t, userEmail, ssf.NewVerificationEvent()))
+	if !errors.Is(err, ocsf.ErrUnsupportedEvent) {
+		t.Errorf("Export() error = %v, want ErrUnsupportedEvent", err)
+	}
+}
+
+func TestExporter_EventExtensions(t *testing.T) {
+	userEmail, _ := subject.NewEmailSubject("user@example.com")
+
+	evt := caep.NewSessionRevokedEvent().WithEventTimestamp(1700000000)
+	evt.SetExtension("x_vendor_session", json.RawMessage(`"s-42"`))
+
+	record, err := ocsf.NewExporter().Export(newSecEvent(t, userEmail, evt))
+	if err != nil {
+		t.Fatalf("Export() error = %v", err)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got struct {
+		Unmapped struct {
+			Event map[string]interface{} `json:"event"`
+		} `json:"unmapped"`
+	}
+
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got.Unmapped.Event["x_vendor_session"] != "s-42" {
+		t.Errorf("unmapped.event = %v, want x_vendor_session", got.Unmapped.Event)
+	}
+}
@@ -0,0 +1,130 @@
+// Package ocsf exports SecEvents as Open Cybersecurity Schema Framework (OCSF) records
+// for SIEM ingestion.
+package ocsf
+
+// Version is the OCSF schema version the records conform to
+const Version = "1.3.0"
+
+// CategoryIdentityAccessManagement is the OCSF category of all exported classes
+const CategoryIdentityAccessManagement = 3
+
+// OCSF class UIDs
+const (
+	ClassAccountChange    = 3001
+	ClassAuthentication   = 3002
+	ClassEntityManagement = 3004
+)
+
+// Authentication activity IDs
+const (
+	ActivityLogon  = 1
+	ActivityLogoff = 2
+)
+
+// Account Change activity IDs
+const (
+	ActivityAccountEnable         = 2
+	ActivityAccountPasswordChange = 3
+	ActivityAccountPasswordReset  = 4
+	ActivityAccountDisable        = 5
+	ActivityAccountDelete         = 6
+	ActivityAccountMFAEnable      = 10
+	ActivityAccountMFADisable     = 11
+)
+
+// Entity Management activity IDs
+const (
+	ActivityEntityUpdate = 3
+)
+
+// ActivityOther is the activity ID used by every class for activities without a dedicated ID
+const ActivityOther = 99
+
+// Severity IDs
+const (
+	SeverityInformational = 1
+	SeverityMedium        = 3
+	SeverityHigh          = 4
+)
+
+var classNames = map[int]string{
+	ClassAccountChange:    "Account Change",
+	ClassAuthentication:   "Authentication",
+	ClassEntityManagement: "Entity Management",
+}
+
+var severityNames = map[int]string{
+	SeverityInformational: "Informational",
+	SeverityMedium:        "Medium",
+	SeverityHigh:          "High",
+}
+
+// Record is an OCSF event record of the Identity & Access Management category
+type Record struct {
+	ActivityID   int    `json:"activity_id"`
+	ActivityName string `json:"activity_name"`
+	CategoryUID  int    `json:"category_uid"`
+	CategoryName string `json:"category_name"`
+	ClassUID     int    `json:"class_uid"`
+	ClassName    string `json:"class_name"`
+	TypeUID      int    `json:"type_uid"`
+	TypeName     string `json:"type_name"`
+	SeverityID   int    `json:"severity_id"`
+	Severity     string `json:"severity"`
+
+	// Time is the time of the event in milliseconds since the epoch
+	Time     int64    `json:"time"`
+	Message  string   `json:"message,omitempty"`
+	Metadata Metadata `json:"metadata"`
+
+	User    *User          `json:"user,omitempty"`
+	Device  *Device        `json:"device,omitempty"`
+	Session *Session       `json:"session,omitempty"`
+	Entity  *ManagedEntity `json:"entity,omitempty"`
+
+	// Unmapped carries the SecEvent data without an OCSF attribute: the event type,
+	// initiating_entity, localized reasons and the full sub_id
+	Unmapped map[string]interface{} `json:"unmapped,omitempty"`
+}
+
+// Metadata describes the origin of a record
+type Metadata struct {
+	Version        string  `json:"version"`
+	Product        Product `json:"product"`
+	UID            string  `json:"uid,omitempty"`
+	CorrelationUID string  `json:"correlation_uid,omitempty"`
+	LoggedTime     int64   `json:"logged_time,omitempty"`
+}
+
+// Product identifies the transmitter that produced the SecEvent
+type Product struct {
+	Name       string `json:"name"`
+	VendorName string `json:"vendor_name,omitempty"`
+}
+
+// User is the OCSF user object
+type User struct {
+	UID       string `json:"uid,omitempty"`
+	Name      string `json:"name,omitempty"`
+	EmailAddr string `json:"email_addr,omitempty"`
+}
+
+// Device is the OCSF device object
+type Device struct {
+	UID  string `json:"uid,omitempty"`
+	Name string `json:"name,omitempty"`
+	Type string `json:"type,omitempty"`
+}
+
+// Session is the OCSF session object
+type Session struct {
+	UID string `json:"uid,omitempty"`
+}
+
+// ManagedEntity is the OCSF managed entity object of Entity Management records
+type ManagedEntity struct {
+	UID  string      `json:"uid,omitempty"`
+	Name string      `json:"name,omitempty"`
+	Type string      `json:"type,omitempty"`
+	Data interface{} `json:"data,omitempty"`
+}
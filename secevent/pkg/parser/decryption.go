@@ -0,0 +1,67 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+)
+
+// WithDecryptionKey adds a private key used to decrypt encrypted (nested JWE) SecEvents.
+// It can be supplied multiple times, e.g. while rotating keys; each key is tried in turn.
+func WithDecryptionKey(key interface{}) Option {
+	return func(p *Parser) {
+		p.decryptionKeys = append(p.decryptionKeys, key)
+	}
+}
+
+// isEncrypted reports whether the token uses the JWE compact serialization
+func isEncrypted(tokenString string) bool {
+	return strings.Count(tokenString, ".") == 4
+}
+
+// isAllowedKeyEncryptionAlgorithm restricts decryption to asymmetric key management algorithms
+func isAllowedKeyEncryptionAlgorithm(alg jwa.KeyEncryptionAlgorithm) bool {
+	switch alg {
+	case jwa.RSA_OAEP, jwa.RSA_OAEP_256,
+		jwa.ECDH_ES, jwa.ECDH_ES_A128KW, jwa.ECDH_ES_A192KW, jwa.ECDH_ES_A256KW:
+		return true
+	default:
+		return false
+	}
+}
+
+// decrypt unwraps an encrypted SecEvent and returns the nested signed token.
+// Tokens that are not encrypted are returned unchanged.
+func (p *Parser) decrypt(tokenString string) (string, error) {
+	if !isEncrypted(tokenString) {
+		return tokenString, nil
+	}
+
+	if len(p.decryptionKeys) == 0 {
+		return "", fmt.Errorf("token is encrypted but no decryption key is configured")
+	}
+
+	message, err := jwe.Parse([]byte(tokenString))
+	if err != nil {
+		return "", fmt.Errorf("failed to parse encrypted token: %w", err)
+	}
+
+	alg := message.ProtectedHeaders().Algorithm()
+	if !isAllowedKeyEncryptionAlgorithm(alg) {
+		return "", fmt.Errorf("unsupported key encryption algorithm: %s", alg)
+	}
+
+	options := make([]jwe.DecryptOption, 0, len(p.decryptionKeys))
+	for _, key := range p.decryptionKeys {
+		options = append(options, jwe.WithKey(alg, key))
+	}
+
+	plaintext, err := jwe.Decrypt([]byte(tokenString), options...)
+	if err != nil {
+		return "", fmt.Errorf("failed to decrypt token: %w", err)
+	}
+
+	return string(plaintext), nil
+}
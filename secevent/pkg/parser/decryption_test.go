@@ -0,0 +1,162 @@
+package parser
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"strings"
+	"testing"
+
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/builder"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/signing"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+func newTestSecEvent(t *testing.T) *token.SecEvent {
+	t.Helper()
+
+	userEmail, err := subject.NewEmailSubject("user@example.com")
+	if err != nil {
+		t.Fatalf("NewEmailSubject() error = %v", err)
+	}
+
+	return builder.NewBuilder(builder.WithDefaultIssuer("https://issuer.example.com")).
+		NewSecEvent().
+		WithAudience("https://receiver.example.com").
+		WithSubject(userEmail).
+		WithEvent(caep.NewSessionRevokedEvent().WithEventTimestamp(1700000000))
+}
+
+func TestParseEncryptedSecEvent(t *testing.T) {
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate signing key: %v", err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	tests := []struct {
+		name          string
+		algorithm     jwa.KeyEncryptionAlgorithm
+		publicKey     interface{}
+		decryptionKey interface{}
+	}{
+		{name: "RSA-OAEP", algorithm: jwa.RSA_OAEP, publicKey: &rsaKey.PublicKey, decryptionKey: rsaKey},
+		{name: "RSA-OAEP-256", algorithm: jwa.RSA_OAEP_256, publicKey: &rsaKey.PublicKey, decryptionKey: rsaKey},
+		{name: "ECDH-ES", algorithm: jwa.ECDH_ES, publicKey: &ecKey.PublicKey, decryptionKey: ecKey},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := signing.NewSigner(signingKey,
+				signing.WithKeyID("key-1"),
+				signing.WithEncryption(tt.publicKey, tt.algorithm))
+			if err != nil {
+				t.Fatalf("NewSigner() error = %v", err)
+			}
+
+			encrypted, err := signer.Sign(newTestSecEvent(t))
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			if got := strings.Count(encrypted, "."); got != 4 {
+				t.Fatalf("expected compact JWE with 5 segments, got %d separators", got)
+			}
+
+			p := NewParser(
+				WithPublicKey(&signingKey.PublicKey, "key-1"),
+				WithDecryptionKey(tt.decryptionKey),
+				WithExpectedIssuer("https://issuer.example.com"),
+			)
+
+			secEvent, err := p.ParseSecEvent(encrypted)
+			if err != nil {
+				t.Fatalf("ParseSecEvent() error = %v", err)
+			}
+
+			if secEvent.Event.Type() != caep.EventTypeSessionRevoked {
+				t.Errorf("unexpected event type %s", secEvent.Event.Type())
+			}
+
+			if _, err := NewParser(WithPublicKey(&signingKey.PublicKey, "key-1")).ParseSecEvent(encrypted); err == nil {
+				t.Error("expected error when no decryption key is configured")
+			}
+		})
+	}
+}
+
+func TestNewSigner_InvalidEncryptionKey(t *testing.T) {
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate signing key: %v", err)
+	}
+
+	_, err = signing.NewSigner(signingKey, signing.WithEncryption(&signingKey.PublicKey, jwa.RSA_OAEP))
+	if err == nil {
+		t.Fatal("expected error for mismatched key encryption algorithm")
+	}
+}
+
+func TestNewSigner_EncryptionKeyID(t *testing.T) {
+	signingKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate signing key: %v", err)
+	}
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	encryption := signing.WithEncryption(&rsaKey.PublicKey, jwa.RSA_OAEP)
+	keyID := signing.WithEncryptionKeyID("enc-1")
+
+	tests := []struct {
+		name string
+		opts []signing.SignerOption
+	}{
+		{name: "key ID after encryption", opts: []signing.SignerOption{encryption, keyID}},
+		{name: "key ID before encryption", opts: []signing.SignerOption{keyID, encryption}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := signing.NewSigner(signingKey, tt.opts...)
+			if err != nil {
+				t.Fatalf("NewSigner() error = %v", err)
+			}
+
+			encrypted, err := signer.Sign(newTestSecEvent(t))
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			msg, err := jwe.Parse([]byte(encrypted))
+			if err != nil {
+				t.Fatalf("jwe.Parse() error = %v", err)
+			}
+
+			if kid := msg.ProtectedHeaders().KeyID(); kid != "enc-1" {
+				t.Errorf("kid = %q, want enc-1", kid)
+			}
+		})
+	}
+
+	if _, err := signing.NewSigner(signingKey, keyID); err == nil {
+		t.Error("expected error for an encryption key ID without encryption")
+	}
+}
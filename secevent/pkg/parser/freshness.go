@@ -0,0 +1,136 @@
+package parser
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+// FreshnessErrorCode identifies why a verified SecEvent was rejected as not fresh
+type FreshnessErrorCode string
+
+const (
+	// ErrCodeMissingIssuedAt is returned when a freshness policy is configured but the token has no iat claim
+	ErrCodeMissingIssuedAt FreshnessErrorCode = "missing_issued_at"
+	// ErrCodeIssuedInFuture is returned when iat is later than now plus the allowed clock skew
+	ErrCodeIssuedInFuture FreshnessErrorCode = "issued_in_future"
+	// ErrCodeTokenTooOld is returned when iat is older than the maximum token age
+	ErrCodeTokenTooOld FreshnessErrorCode = "token_too_old"
+	// ErrCodeEventTooOld is returned when an event's event_timestamp is older than the maximum event age
+	ErrCodeEventTooOld FreshnessErrorCode = "event_too_old"
+)
+
+// FreshnessError is returned when a SecEvent has a valid signature but fails the parser's
+// freshness policy. Use errors.As to distinguish it from verification failures.
+type FreshnessError struct {
+	Code      FreshnessErrorCode
+	Message   string
+	EventType event.EventType // Set for ErrCodeEventTooOld
+	Timestamp time.Time       // The offending iat or event_timestamp
+}
+
+// Error returns the string representation of the error
+func (e *FreshnessError) Error() string {
+	if e.EventType != "" {
+		return fmt.Sprintf("%s: %s (event: %s)", e.Code, e.Message, e.EventType)
+	}
+
+	return fmt.Sprintf("%s: %s", e.Code, e.Message)
+}
+
+// WithMaxTokenAge rejects SecEvents whose iat claim is older than maxAge.
+// Tokens without an iat claim are rejected when this is set.
+func WithMaxTokenAge(maxAge time.Duration) Option {
+	return func(p *Parser) {
+		p.maxTokenAge = maxAge
+	}
+}
+
+// WithClockSkew sets the tolerance applied to all freshness checks to allow for clock
+// differences between transmitter and receiver
+func WithClockSkew(skew time.Duration) Option {
+	return func(p *Parser) {
+		p.clockSkew = skew
+	}
+}
+
+// WithRejectFutureIssuedAt rejects SecEvents whose iat claim is later than now plus the clock skew.
+// Tokens without an iat claim are rejected when this is set.
+func WithRejectFutureIssuedAt() Option {
+	return func(p *Parser) {
+		p.rejectFutureIssuedAt = true
+	}
+}
+
+// WithMaxEventAge rejects SecEvents containing an event whose event_timestamp (as carried by
+// CAEP events) is older than maxAge. Events without an event_timestamp are not checked.
+func WithMaxEventAge(maxAge time.Duration) Option {
+	return func(p *Parser) {
+		p.maxEventAge = maxAge
+	}
+}
+
+// timestampedEvent is implemented by events carrying an event_timestamp
+type timestampedEvent interface {
+	GetEventTimestamp() (int64, bool)
+}
+
+// checkFreshness applies the configured freshness policy to a verified token
+func (p *Parser) checkFreshness(issuedAt *jwt.NumericDate, events ...event.Event) error {
+	now := p.now()
+
+	if p.maxTokenAge > 0 || p.rejectFutureIssuedAt {
+		if issuedAt == nil {
+			return &FreshnessError{
+				Code:    ErrCodeMissingIssuedAt,
+				Message: "issued at (iat) claim is required",
+			}
+		}
+
+		iat := issuedAt.Time
+
+		if p.rejectFutureIssuedAt && iat.After(now.Add(p.clockSkew)) {
+			return &FreshnessError{
+				Code:      ErrCodeIssuedInFuture,
+				Message:   fmt.Sprintf("token issued at %s is in the future", iat.UTC().Format(time.RFC3339)),
+				Timestamp: iat,
+			}
+		}
+
+		if p.maxTokenAge > 0 && now.Sub(iat) > p.maxTokenAge+p.clockSkew {
+			return &FreshnessError{
+				Code:      ErrCodeTokenTooOld,
+				Message:   fmt.Sprintf("token issued at %s exceeds maximum age of %s", iat.UTC().Format(time.RFC3339), p.maxTokenAge),
+				Timestamp: iat,
+			}
+		}
+	}
+
+	if p.maxEventAge > 0 {
+		for _, evt := range events {
+			timestamped, ok := evt.(timestampedEvent)
+			if !ok {
+				continue
+			}
+
+			unix, ok := timestamped.GetEventTimestamp()
+			if !ok {
+				continue
+			}
+
+			eventTime := time.Unix(unix, 0)
+			if now.Sub(eventTime) > p.maxEventAge+p.clockSkew {
+				return &FreshnessError{
+					Code:      ErrCodeEventTooOld,
+					Message:   fmt.Sprintf("event occurred at %s exceeds maximum age of %s", eventTime.UTC().Format(time.RFC3339), p.maxEventAge),
+					EventType: evt.Type(),
+					Timestamp: eventTime,
+				}
+			}
+		}
+	}
+
+	return nil
+}
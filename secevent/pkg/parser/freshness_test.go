@@ -0,0 +1,200 @@
+package parser
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/signing"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+func TestParser_Freshness(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	signer, err := signing.NewSigner(key, signing.WithKeyID("key-1"))
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+
+	sign := func(issuedAt time.Time, eventTimestamp int64) string {
+		secEvent := newTestSecEvent(t).
+			WithEvent(caep.NewSessionRevokedEvent().WithEventTimestamp(eventTimestamp))
+		secEvent.IssuedAt = jwt.NewNumericDate(issuedAt)
+
+		signed, err := signer.Sign(secEvent)
+		if err != nil {
+			t.Fatalf("Sign() error = %v", err)
+		}
+
+		return signed
+	}
+
+	tests := []struct {
+		name     string
+		opts     []Option
+		issuedAt time.Time
+		eventAge time.Duration
+		wantCode FreshnessErrorCode
+	}{
+		{
+			name:     "no policy accepts old token",
+			issuedAt: now.Add(-365 * 24 * time.Hour),
+		},
+		{
+			name:     "within max age",
+			opts:     []Option{WithMaxTokenAge(time.Hour)},
+			issuedAt: now.Add(-30 * time.Minute),
+		},
+		{
+			name:     "exceeds max age",
+			opts:     []Option{WithMaxTokenAge(time.Hour)},
+			issuedAt: now.Add(-2 * time.Hour),
+			wantCode: ErrCodeTokenTooOld,
+		},
+		{
+			name:     "clock skew extends max age",
+			opts:     []Option{WithMaxTokenAge(time.Hour), WithClockSkew(5 * time.Minute)},
+			issuedAt: now.Add(-62 * time.Minute),
+		},
+		{
+			name:     "future iat rejected",
+			opts:     []Option{WithRejectFutureIssuedAt()},
+			issuedAt: now.Add(10 * time.Minute),
+			wantCode: ErrCodeIssuedInFuture,
+		},
+		{
+			name:     "future iat within skew",
+			opts:     []Option{WithRejectFutureIssuedAt(), WithClockSkew(time.Minute)},
+			issuedAt: now.Add(30 * time.Second),
+		},
+		{
+			name:     "old event_timestamp rejected",
+			opts:     []Option{WithMaxEventAge(time.Hour)},
+			issuedAt: now,
+			eventAge: 3 * time.Hour,
+			wantCode: ErrCodeEventTooOld,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]Option{WithPublicKey(&key.PublicKey, "key-1")}, tt.opts...)
+
+			p := NewParser(opts...)
+			p.now = func() time.Time { return now }
+
+			tokenString := sign(tt.issuedAt, now.Add(-tt.eventAge).Unix())
+
+			_, err := p.ParseSecEvent(tokenString)
+
+			var freshnessErr *FreshnessError
+
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("ParseSecEvent() error = %v", err)
+				}
+
+				return
+			}
+
+			if !errors.As(err, &freshnessErr) {
+				t.Fatalf("expected FreshnessError, got %v", err)
+			}
+
+			if freshnessErr.Code != tt.wantCode {
+				t.Errorf("code = %s, want %s", freshnessErr.Code, tt.wantCode)
+			}
+
+			// The multi-event path applies the same policy
+			if _, err := p.ParseMultiSecEvent(tokenString); !errors.As(err, &freshnessErr) {
+				t.Errorf("ParseMultiSecEvent() expected FreshnessError, got %v", err)
+			}
+		})
+	}
+}
+
+func TestParser_FreshnessMultiEventOrder(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	signer, err := signing.NewSigner(key, signing.WithKeyID("key-1"))
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+
+	userEmail, err := subject.NewEmailSubject("user@example.com")
+	if err != nil {
+		t.Fatalf("NewEmailSubject() error = %v", err)
+	}
+
+	now := time.Unix(1700000000, 0)
+	stale := now.Add(-3 * time.Hour).Unix()
+
+	multi := token.NewMultiSecEvent().
+		WithIssuer("https://issuer.example.com").
+		WithID("multi-1").
+		WithSubject(userEmail).
+		WithEvent(caep.NewSessionRevokedEvent().WithEventTimestamp(stale)).
+		WithEvent(caep.NewCredentialChangeEvent(caep.CredentialTypePassword, caep.ChangeTypeUpdate).WithEventTimestamp(stale))
+	multi.IssuedAt = jwt.NewNumericDate(now)
+
+	signed, err := signer.Sign(multi)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	p := NewParser(WithPublicKey(&key.PublicKey, "key-1"), WithMaxEventAge(time.Hour))
+	p.now = func() time.Time { return now }
+
+	for i := 0; i < 20; i++ {
+		_, err := p.ParseMultiSecEvent(signed)
+
+		var freshnessErr *FreshnessError
+		if !errors.As(err, &freshnessErr) {
+			t.Fatalf("expected FreshnessError, got %v", err)
+		}
+
+		if freshnessErr.EventType != caep.EventTypeCredentialChange {
+			t.Fatalf("event = %s, want %s", freshnessErr.EventType, caep.EventTypeCredentialChange)
+		}
+	}
+}
+
+func TestParser_FreshnessDoesNotMaskSignatureErrors(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	p := NewParser(WithPublicKey(&key.PublicKey, "key-1"), WithMaxTokenAge(time.Nanosecond))
+
+	_, err = p.ParseSecEvent(signTestSecEvent(t, other, "key-1", "https://issuer.example.com", "https://receiver.example.com"))
+
+	var freshnessErr *FreshnessError
+	if errors.As(err, &freshnessErr) {
+		t.Fatalf("expected signature error, got freshness error %v", err)
+	}
+
+	if !errors.Is(err, jwt.ErrTokenSignatureInvalid) {
+		t.Errorf("expected jwt.ErrTokenSignatureInvalid, got %v", err)
+	}
+}
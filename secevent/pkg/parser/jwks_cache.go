@@ -0,0 +1,228 @@
+package parser
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+const (
+	// DefaultJWKSCacheTTL is how long a fetched JWKS is used when the response has no Cache-Control max-age
+	DefaultJWKSCacheTTL = 15 * time.Minute
+
+	// DefaultJWKSRefreshInterval is the minimum time between two fetches of the same JWKS
+	DefaultJWKSRefreshInterval = 30 * time.Second
+
+	// maxJWKSResponseBytes bounds the size of a JWKS response body
+	maxJWKSResponseBytes = 1 << 20
+)
+
+// jwksCache caches a remote JWKS, honoring Cache-Control, refreshing on unknown
+// key IDs (rate limited) and serving the last known key set if a refresh fails.
+// Fetches run without holding the lock: cached keys stay available while a refresh is in
+// flight, and concurrent lookups that need the refreshed key set share a single fetch.
+type jwksCache struct {
+	url             *url.URL
+	client          *http.Client
+	ttl             time.Duration
+	refreshInterval time.Duration
+	now             func() time.Time
+
+	mu          sync.Mutex
+	keySet      jwk.Set
+	expiresAt   time.Time
+	lastAttempt time.Time
+	inflight    *jwksRefresh
+}
+
+// jwksRefresh is a fetch of the JWKS in progress; done is closed once it completes
+type jwksRefresh struct {
+	done chan struct{}
+	err  error
+}
+
+func newJWKSCache(jwksURL *url.URL, client *http.Client, ttl, refreshInterval time.Duration) *jwksCache {
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	if ttl <= 0 {
+		ttl = DefaultJWKSCacheTTL
+	}
+
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultJWKSRefreshInterval
+	}
+
+	return &jwksCache{
+		url:             jwksURL,
+		client:          client,
+		ttl:             ttl,
+		refreshInterval: refreshInterval,
+		now:             time.Now,
+	}
+}
+
+// lookupKey returns the key with the given kid, fetching or refreshing the JWKS as needed
+func (c *jwksCache) lookupKey(ctx context.Context, kid string) (jwk.Key, error) {
+	c.mu.Lock()
+
+	now := c.now()
+	keySet := c.keySet
+	expired := keySet == nil || now.After(c.expiresAt)
+
+	var cached jwk.Key
+	if keySet != nil {
+		cached, _ = keySet.LookupKeyID(kid)
+	}
+
+	// Fresh keys, and stale keys while another lookup refreshes them, are served immediately
+	if cached != nil && (!expired || c.inflight != nil) {
+		c.mu.Unlock()
+
+		return cached, nil
+	}
+
+	refresh := c.inflight
+	if refresh == nil {
+		// Rate limit refreshes, serving the stale key set if the last attempt was recent.
+		// An unknown kid may mean the transmitter rotated its keys.
+		if keySet != nil && now.Sub(c.lastAttempt) < c.refreshInterval {
+			c.mu.Unlock()
+
+			if cached != nil {
+				return cached, nil
+			}
+
+			return nil, fmt.Errorf("no key found for kid %s", kid)
+		}
+
+		refresh = &jwksRefresh{done: make(chan struct{})}
+		c.inflight = refresh
+		c.lastAttempt = now
+		c.mu.Unlock()
+
+		c.refresh(ctx, now, refresh)
+	} else {
+		c.mu.Unlock()
+
+		select {
+		case <-refresh.done:
+		case <-ctx.Done():
+			return nil, fmt.Errorf("no key found for kid %s: %w", kid, ctx.Err())
+		}
+	}
+
+	c.mu.Lock()
+	keySet = c.keySet
+	c.mu.Unlock()
+
+	if keySet == nil {
+		return nil, refresh.err
+	}
+
+	if key, found := keySet.LookupKeyID(kid); found {
+		return key, nil
+	}
+
+	if refresh.err != nil {
+		return nil, fmt.Errorf("no key found for kid %s: %w", kid, refresh.err)
+	}
+
+	return nil, fmt.Errorf("no key found for kid %s", kid)
+}
+
+// refresh fetches the JWKS, replaces the cached key set on success and completes the
+// in-flight refresh. Must be called without mu held.
+func (c *jwksCache) refresh(ctx context.Context, now time.Time, refresh *jwksRefresh) {
+	keySet, ttl, err := c.fetch(ctx)
+
+	c.mu.Lock()
+
+	if err == nil {
+		c.keySet = keySet
+		c.expiresAt = now.Add(ttl)
+	}
+
+	refresh.err = err
+	c.inflight = nil
+	c.mu.Unlock()
+
+	close(refresh.done)
+}
+
+func (c *jwksCache) fetch(ctx context.Context) (jwk.Set, time.Duration, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.url.String(), nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create JWKS request: %w", err)
+	}
+
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch JWKS: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("failed to fetch JWKS: unexpected status code %d", resp.StatusCode)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxJWKSResponseBytes))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read JWKS response: %w", err)
+	}
+
+	keySet, err := jwk.Parse(body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to parse JWKS: %w", err)
+	}
+
+	ttl := c.ttl
+	if maxAge, ok := parseCacheControlMaxAge(resp.Header.Get("Cache-Control")); ok {
+		ttl = maxAge
+	}
+
+	// Never cache for less than the refresh interval, so a no-cache response does not
+	// turn into one fetch per token
+	if ttl < c.refreshInterval {
+		ttl = c.refreshInterval
+	}
+
+	return keySet, ttl, nil
+}
+
+// parseCacheControlMaxAge extracts the freshness lifetime from a Cache-Control header.
+// no-store and no-cache are treated as a max-age of zero.
+func parseCacheControlMaxAge(header string) (time.Duration, bool) {
+	if header == "" {
+		return 0, false
+	}
+
+	for _, directive := range strings.Split(header, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+
+		switch {
+		case directive == "no-store", directive == "no-cache":
+			return 0, true
+		case strings.HasPrefix(directive, "max-age="):
+			seconds, err := strconv.ParseInt(strings.Trim(directive[len("max-age="):], `"`), 10, 64)
+			if err != nil || seconds < 0 {
+				continue
+			}
+
+			return time.Duration(seconds) * time.Second, true
+		}
+	}
+
+	return 0, false
+}
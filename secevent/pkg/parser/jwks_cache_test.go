@@ -0,0 +1,293 @@
+package parser
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+type jwksServer struct {
+	server       *httptest.Server
+	hits         atomic.Int32
+	fail         atomic.Bool
+	keySet       atomic.Value
+	cacheControl string
+}
+
+func newJWKSServer(t *testing.T, cacheControl string) *jwksServer {
+	t.Helper()
+
+	s := &jwksServer{cacheControl: cacheControl}
+	s.keySet.Store(jwk.NewSet())
+	s.server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.hits.Add(1)
+
+		if s.fail.Load() {
+			http.Error(w, "unavailable", http.StatusServiceUnavailable)
+			return
+		}
+
+		if s.cacheControl != "" {
+			w.Header().Set("Cache-Control", s.cacheControl)
+		}
+
+		_ = json.NewEncoder(w).Encode(s.keySet.Load())
+	}))
+	t.Cleanup(s.server.Close)
+
+	return s
+}
+
+func (s *jwksServer) addKey(t *testing.T, kid string) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	s.addPublicKey(t, kid, &privateKey.PublicKey)
+}
+
+func (s *jwksServer) addPublicKey(t *testing.T, kid string, publicKey interface{}) {
+	t.Helper()
+
+	key, err := jwk.FromRaw(publicKey)
+	if err != nil {
+		t.Fatalf("failed to create JWK: %v", err)
+	}
+
+	if err := key.Set(jwk.KeyIDKey, kid); err != nil {
+		t.Fatalf("failed to set kid: %v", err)
+	}
+
+	keySet, err := s.keySet.Load().(jwk.Set).Clone()
+	if err != nil {
+		t.Fatalf("failed to clone key set: %v", err)
+	}
+
+	if err := keySet.AddKey(key); err != nil {
+		t.Fatalf("failed to add key: %v", err)
+	}
+
+	s.keySet.Store(keySet)
+}
+
+func newTestCache(t *testing.T, s *jwksServer, clock *time.Time) *jwksCache {
+	t.Helper()
+
+	jwksURL, err := url.Parse(s.server.URL)
+	if err != nil {
+		t.Fatalf("failed to parse URL: %v", err)
+	}
+
+	cache := newJWKSCache(jwksURL, s.server.Client(), time.Minute, 10*time.Second)
+	cache.now = func() time.Time { return *clock }
+
+	return cache
+}
+
+func TestJWKSCache_CachesUntilExpiry(t *testing.T) {
+	s := newJWKSServer(t, "")
+	s.addKey(t, "key-1")
+
+	clock := time.Unix(1700000000, 0)
+	cache := newTestCache(t, s, &clock)
+
+	for i := 0; i < 5; i++ {
+		if _, err := cache.lookupKey(context.Background(), "key-1"); err != nil {
+			t.Fatalf("lookupKey() error = %v", err)
+		}
+	}
+
+	if got := s.hits.Load(); got != 1 {
+		t.Errorf("expected 1 fetch, got %d", got)
+	}
+
+	clock = clock.Add(2 * time.Minute)
+
+	if _, err := cache.lookupKey(context.Background(), "key-1"); err != nil {
+		t.Fatalf("lookupKey() error = %v", err)
+	}
+
+	if got := s.hits.Load(); got != 2 {
+		t.Errorf("expected refetch after TTL, got %d fetches", got)
+	}
+}
+
+func TestJWKSCache_HonorsCacheControl(t *testing.T) {
+	s := newJWKSServer(t, "public, max-age=3600")
+	s.addKey(t, "key-1")
+
+	clock := time.Unix(1700000000, 0)
+	cache := newTestCache(t, s, &clock)
+
+	if _, err := cache.lookupKey(context.Background(), "key-1"); err != nil {
+		t.Fatalf("lookupKey() error = %v", err)
+	}
+
+	clock = clock.Add(30 * time.Minute)
+
+	if _, err := cache.lookupKey(context.Background(), "key-1"); err != nil {
+		t.Fatalf("lookupKey() error = %v", err)
+	}
+
+	if got := s.hits.Load(); got != 1 {
+		t.Errorf("expected max-age to extend the TTL, got %d fetches", got)
+	}
+}
+
+func TestJWKSCache_UnknownKidRefreshIsRateLimited(t *testing.T) {
+	s := newJWKSServer(t, "")
+	s.addKey(t, "key-1")
+
+	clock := time.Unix(1700000000, 0)
+	cache := newTestCache(t, s, &clock)
+
+	if _, err := cache.lookupKey(context.Background(), "key-1"); err != nil {
+		t.Fatalf("lookupKey() error = %v", err)
+	}
+
+	// Rotation happens on the transmitter
+	s.addKey(t, "key-2")
+
+	if _, err := cache.lookupKey(context.Background(), "key-2"); err == nil {
+		t.Fatal("expected refresh to be rate limited right after the initial fetch")
+	}
+
+	clock = clock.Add(11 * time.Second)
+
+	if _, err := cache.lookupKey(context.Background(), "key-2"); err != nil {
+		t.Fatalf("lookupKey() after refresh interval error = %v", err)
+	}
+
+	for i := 0; i < 5; i++ {
+		_, _ = cache.lookupKey(context.Background(), "unknown")
+	}
+
+	if got := s.hits.Load(); got != 2 {
+		t.Errorf("expected 2 fetches, got %d", got)
+	}
+}
+
+func TestJWKSCache_ServesStaleOnError(t *testing.T) {
+	s := newJWKSServer(t, "")
+	s.addKey(t, "key-1")
+
+	clock := time.Unix(1700000000, 0)
+	cache := newTestCache(t, s, &clock)
+
+	if _, err := cache.lookupKey(context.Background(), "key-1"); err != nil {
+		t.Fatalf("lookupKey() error = %v", err)
+	}
+
+	s.fail.Store(true)
+	clock = clock.Add(2 * time.Minute)
+
+	if _, err := cache.lookupKey(context.Background(), "key-1"); err != nil {
+		t.Fatalf("expected stale key set to be served, got error = %v", err)
+	}
+}
+
+func TestJWKSCache_ServesCachedKeysDuringRefresh(t *testing.T) {
+	s := newJWKSServer(t, "")
+	s.addKey(t, "key-1")
+
+	fetching := make(chan struct{}, 1)
+	release := make(chan struct{})
+	handler := s.server.Config.Handler
+
+	// Block every fetch after the first until released
+	s.server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.hits.Load() > 0 {
+			fetching <- struct{}{}
+			<-release
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+
+	clock := time.Unix(1700000000, 0)
+	cache := newTestCache(t, s, &clock)
+
+	if _, err := cache.lookupKey(context.Background(), "key-1"); err != nil {
+		t.Fatalf("lookupKey() error = %v", err)
+	}
+
+	s.addKey(t, "key-2")
+	clock = clock.Add(2 * time.Minute)
+
+	results := make(chan error, 2)
+	lookup := func() {
+		_, err := cache.lookupKey(context.Background(), "key-2")
+		results <- err
+	}
+
+	var releaseOnce sync.Once
+	releaseFetch := func() { releaseOnce.Do(func() { close(release) }) }
+	defer releaseFetch()
+
+	go lookup()
+	<-fetching
+
+	cachedLookup := make(chan error, 1)
+	go func() {
+		_, err := cache.lookupKey(context.Background(), "key-1")
+		cachedLookup <- err
+	}()
+
+	select {
+	case err := <-cachedLookup:
+		if err != nil {
+			t.Fatalf("lookupKey() of a cached kid during a refresh error = %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("lookupKey() of a cached kid waited for the refresh")
+	}
+
+	go lookup()
+	releaseFetch()
+
+	for i := 0; i < 2; i++ {
+		if err := <-results; err != nil {
+			t.Errorf("lookupKey() of the rotated kid error = %v", err)
+		}
+	}
+
+	if got := s.hits.Load(); got != 2 {
+		t.Errorf("expected concurrent lookups to share one refresh, got %d fetches", got)
+	}
+}
+
+func TestParseCacheControlMaxAge(t *testing.T) {
+	tests := []struct {
+		header string
+		want   time.Duration
+		wantOK bool
+	}{
+		{header: "", wantOK: false},
+		{header: "public", wantOK: false},
+		{header: "max-age=60", want: time.Minute, wantOK: true},
+		{header: "public, MAX-AGE=120, must-revalidate", want: 2 * time.Minute, wantOK: true},
+		{header: "no-store", want: 0, wantOK: true},
+		{header: "max-age=abc", wantOK: false},
+	}
+
+	for _, tt := range tests {
+		got, ok := parseCacheControlMaxAge(tt.header)
+		if got != tt.want || ok != tt.wantOK {
+			t.Errorf("parseCacheControlMaxAge(%q) = %v, %v; want %v, %v", tt.header, got, ok, tt.want, tt.wantOK)
+		}
+	}
+}
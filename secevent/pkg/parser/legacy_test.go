@@ -0,0 +1,53 @@
+package parser
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+)
+
+func TestParser_LegacySubject(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	claims := conformingClaims()
+	delete(claims, "sub_id")
+	claims["events"] = map[string]interface{}{
+		string(caep.EventTypeSessionRevoked): map[string]interface{}{
+			"subject": map[string]interface{}{"subject_type": "email", "email": "user@example.com"},
+		},
+	}
+
+	signed := signProfileTestToken(t, key, nil, claims)
+
+	if _, err := NewParser(WithPublicKey(&key.PublicKey, "key-1")).ParseSecEvent(signed); err == nil {
+		t.Fatal("ParseSecEvent() accepted a legacy token without WithLegacySubject")
+	}
+
+	p := NewParser(WithPublicKey(&key.PublicKey, "key-1"), WithLegacySubject())
+
+	set, err := p.ParseSecEvent(signed)
+	if err != nil {
+		t.Fatalf("ParseSecEvent() error = %v", err)
+	}
+
+	want, _ := subject.NewEmailSubject("user@example.com")
+	if !subject.Equal(set.Subject, want) {
+		t.Errorf("Subject = %v, want %v", set.Subject, want)
+	}
+
+	multi, err := p.ParseMultiSecEventNoVerify(signed)
+	if err != nil {
+		t.Fatalf("ParseMultiSecEventNoVerify() error = %v", err)
+	}
+
+	if !subject.Equal(multi.Subject, want) {
+		t.Errorf("multi Subject = %v, want %v", multi.Subject, want)
+	}
+}
@@ -0,0 +1,86 @@
+package parser
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+)
+
+const unknownEventType = event.EventType("https://vendor.example.com/event-type/widget-changed")
+
+// unknownEventJSON deliberately uses key order and number formatting that re-encoding would not preserve
+const unknownEventJSON = `{"zeta":1.50,"alpha":{"nested":[3,2,1]},"note":"kept as sent"}`
+
+func TestParser_LenientEvents(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	claims := jwt.MapClaims{
+		"iss":    "https://issuer.example.com",
+		"jti":    "lenient-1",
+		"iat":    1700000000,
+		"sub_id": map[string]interface{}{"format": "email", "email": "user@example.com"},
+		"events": map[string]interface{}{
+			string(unknownEventType):             json.RawMessage(unknownEventJSON),
+			string(caep.EventTypeSessionRevoked): map[string]interface{}{},
+		},
+	}
+
+	tok := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	tok.Header["kid"] = "key-1"
+
+	signed, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+
+	if _, err := NewParser(WithPublicKey(&key.PublicKey, "key-1")).ParseMultiSecEvent(signed); err == nil {
+		t.Fatal("expected strict parser to reject unknown event type")
+	}
+
+	p := NewParser(WithPublicKey(&key.PublicKey, "key-1"), WithLenientEvents())
+
+	set, err := p.ParseMultiSecEvent(signed)
+	if err != nil {
+		t.Fatalf("ParseMultiSecEvent() error = %v", err)
+	}
+
+	if _, ok := set.Events[caep.EventTypeSessionRevoked].(*caep.SessionRevokedEvent); !ok {
+		t.Errorf("registered event decoded as %T", set.Events[caep.EventTypeSessionRevoked])
+	}
+
+	rawEvent, ok := set.Events[unknownEventType].(*event.RawEvent)
+	if !ok {
+		t.Fatalf("unknown event decoded as %T, want *event.RawEvent", set.Events[unknownEventType])
+	}
+
+	if rawEvent.Type() != unknownEventType {
+		t.Errorf("Type() = %s", rawEvent.Type())
+	}
+
+	marshaled, err := rawEvent.MarshalJSON()
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if !bytes.Equal(marshaled, []byte(unknownEventJSON)) {
+		t.Errorf("round trip changed event JSON:\n got %s\nwant %s", marshaled, unknownEventJSON)
+	}
+
+	var decoded struct {
+		Zeta float64 `json:"zeta"`
+	}
+
+	if err := rawEvent.Decode(&decoded); err != nil || decoded.Zeta != 1.5 {
+		t.Errorf("Decode() = %+v, %v", decoded, err)
+	}
+}
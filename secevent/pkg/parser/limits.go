@@ -0,0 +1,185 @@
+package parser
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Limits bounds the size and shape of untrusted tokens. Limits are checked before the signature
+// is verified, so oversized input never causes a JWKS fetch; MaxTokenBytes is also checked
+// before decryption. A zero field disables that limit.
+type Limits struct {
+	// MaxTokenBytes is the maximum length of the compact token, before and after decryption
+	MaxTokenBytes int
+
+	// MaxEvents is the maximum number of members of the events claim
+	MaxEvents int
+
+	// MaxJSONDepth is the maximum nesting depth of the header and claims; the claims object
+	// itself is depth 1
+	MaxJSONDepth int
+
+	// MaxStringLength is the maximum length in bytes of any JSON string (member names included)
+	// in the header and claims
+	MaxStringLength int
+}
+
+// DefaultLimits are suggested limits for receivers accepting SecEvents from the internet
+var DefaultLimits = Limits{
+	MaxTokenBytes:   64 * 1024,
+	MaxEvents:       32,
+	MaxJSONDepth:    32,
+	MaxStringLength: 8 * 1024,
+}
+
+// Limit identifies which limit a token exceeded
+type Limit string
+
+const (
+	LimitTokenBytes   Limit = "max_token_bytes"
+	LimitEvents       Limit = "max_events"
+	LimitJSONDepth    Limit = "max_json_depth"
+	LimitStringLength Limit = "max_string_length"
+)
+
+// LimitError is returned when a token exceeds one of the parser's limits
+type LimitError struct {
+	Limit  Limit
+	Max    int
+	Actual int
+}
+
+// Error returns the string representation of the error
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("token exceeds %s: %d > %d", e.Limit, e.Actual, e.Max)
+}
+
+// WithLimits bounds the size and shape of accepted tokens; see Limits.
+// Parsers have no limits unless this option is set.
+func WithLimits(limits Limits) Option {
+	return func(p *Parser) {
+		p.limits = limits
+	}
+}
+
+// checkTokenSize enforces MaxTokenBytes
+func (l Limits) checkTokenSize(tokenString string) error {
+	if l.MaxTokenBytes > 0 && len(tokenString) > l.MaxTokenBytes {
+		return &LimitError{Limit: LimitTokenBytes, Max: l.MaxTokenBytes, Actual: len(tokenString)}
+	}
+
+	return nil
+}
+
+// checkSignedToken enforces all limits on a signed (JWS) compact token without verifying it
+func (l Limits) checkSignedToken(tokenString string) error {
+	if err := l.checkTokenSize(tokenString); err != nil {
+		return err
+	}
+
+	if l.MaxEvents <= 0 && l.MaxJSONDepth <= 0 && l.MaxStringLength <= 0 {
+		return nil
+	}
+
+	segments := strings.Split(tokenString, ".")
+	if len(segments) != 3 {
+		return fmt.Errorf("token is not a compact JWS")
+	}
+
+	for i, name := range []string{"header", "claims"} {
+		data, err := base64.RawURLEncoding.DecodeString(segments[i])
+		if err != nil {
+			return fmt.Errorf("failed to decode token %s: %w", name, err)
+		}
+
+		if err := l.checkJSON(data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// jsonFrame tracks an open JSON object or array while scanning
+type jsonFrame struct {
+	object    bool
+	expectKey bool
+	events    bool
+}
+
+// checkJSON scans a JSON document token by token, so arbitrarily deep or large input is
+// rejected without being decoded into memory
+func (l Limits) checkJSON(data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var stack []*jsonFrame
+	var lastKey string
+	events := 0
+
+	valueConsumed := func() {
+		if len(stack) > 0 && stack[len(stack)-1].object {
+			stack[len(stack)-1].expectKey = true
+		}
+	}
+
+	for {
+		tok, err := decoder.Token()
+		if err == io.EOF {
+			if len(stack) > 0 {
+				return fmt.Errorf("malformed token JSON: %w", io.ErrUnexpectedEOF)
+			}
+
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("malformed token JSON: %w", err)
+		}
+
+		switch v := tok.(type) {
+		case json.Delim:
+			switch v {
+			case '{', '[':
+				isEvents := v == '{' && len(stack) == 1 && stack[0].object && lastKey == "events"
+
+				valueConsumed()
+				stack = append(stack, &jsonFrame{object: v == '{', expectKey: v == '{', events: isEvents})
+
+				if l.MaxJSONDepth > 0 && len(stack) > l.MaxJSONDepth {
+					return &LimitError{Limit: LimitJSONDepth, Max: l.MaxJSONDepth, Actual: len(stack)}
+				}
+			default:
+				stack = stack[:len(stack)-1]
+			}
+		case string:
+			if l.MaxStringLength > 0 && len(v) > l.MaxStringLength {
+				return &LimitError{Limit: LimitStringLength, Max: l.MaxStringLength, Actual: len(v)}
+			}
+
+			if len(stack) > 0 && stack[len(stack)-1].expectKey {
+				top := stack[len(stack)-1]
+				top.expectKey = false
+				lastKey = v
+
+				if top.events {
+					events++
+
+					if l.MaxEvents > 0 && events > l.MaxEvents {
+						return &LimitError{Limit: LimitEvents, Max: l.MaxEvents, Actual: events}
+					}
+				}
+
+				continue
+			}
+
+			valueConsumed()
+		default:
+			valueConsumed()
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package parser
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+)
+
+func TestParser_Limits(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	nested := map[string]interface{}{"format": "opaque", "id": "leaf"}
+	for i := 0; i < 40; i++ {
+		nested = map[string]interface{}{"format": "complex", "user": nested}
+	}
+
+	manyEvents := map[string]interface{}{}
+	for i := 0; i < 40; i++ {
+		manyEvents[fmt.Sprintf("https://vendor.example.com/event-type/%d", i)] = map[string]interface{}{}
+	}
+
+	tests := []struct {
+		name      string
+		claims    func(jwt.MapClaims)
+		wantLimit Limit
+	}{
+		{
+			name: "within limits",
+		},
+		{
+			name: "oversized token",
+			claims: func(c jwt.MapClaims) {
+				c["padding"] = strings.Repeat("a", DefaultLimits.MaxTokenBytes)
+			},
+			wantLimit: LimitTokenBytes,
+		},
+		{
+			name: "too many events",
+			claims: func(c jwt.MapClaims) {
+				c["events"] = manyEvents
+			},
+			wantLimit: LimitEvents,
+		},
+		{
+			name: "deeply nested subject",
+			claims: func(c jwt.MapClaims) {
+				c["sub_id"] = nested
+			},
+			wantLimit: LimitJSONDepth,
+		},
+		{
+			name: "long subject component",
+			claims: func(c jwt.MapClaims) {
+				c["sub_id"] = map[string]interface{}{"format": "opaque", "id": strings.Repeat("x", DefaultLimits.MaxStringLength+1)}
+			},
+			wantLimit: LimitStringLength,
+		},
+		{
+			name: "nested events members do not count as events",
+			claims: func(c jwt.MapClaims) {
+				c["vendor"] = map[string]interface{}{"events": manyEvents}
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := newJWKSServer(t, "")
+			server.addPublicKey(t, "key-1", &key.PublicKey)
+
+			claims := conformingClaims()
+			if tt.claims != nil {
+				tt.claims(claims)
+			}
+
+			signed := signProfileTestToken(t, key, nil, claims)
+			p := NewParser(WithJWKSURL(server.server.URL), WithLimits(DefaultLimits))
+
+			secEvent, err := p.ParseSecEvent(signed)
+
+			if tt.wantLimit == "" {
+				if err != nil {
+					t.Fatalf("ParseSecEvent() error = %v", err)
+				}
+
+				if secEvent.ID != "profile-1" || secEvent.Event.Type() != caep.EventTypeSessionRevoked {
+					t.Errorf("parsed %s/%s, want profile-1/%s", secEvent.ID, secEvent.Event.Type(), caep.EventTypeSessionRevoked)
+				}
+
+				return
+			}
+
+			var limitErr *LimitError
+			if !errors.As(err, &limitErr) || limitErr.Limit != tt.wantLimit {
+				t.Fatalf("ParseSecEvent() error = %v, want %s", err, tt.wantLimit)
+			}
+
+			if hits := server.hits.Load(); hits != 0 {
+				t.Errorf("JWKS fetched %d times for a token over the limit", hits)
+			}
+
+			if _, err := p.ParseMultiSecEventNoVerify(signed); !errors.As(err, &limitErr) {
+				t.Errorf("ParseMultiSecEventNoVerify() error = %v, want *LimitError", err)
+			}
+		})
+	}
+}
+
+func TestParser_NoLimitsByDefault(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	claims := conformingClaims()
+	claims["padding"] = strings.Repeat("a", DefaultLimits.MaxTokenBytes)
+	claims["events"] = map[string]interface{}{string(caep.EventTypeSessionRevoked): map[string]interface{}{}}
+
+	signed := signProfileTestToken(t, key, nil, claims)
+
+	if _, err := NewParser(WithPublicKey(&key.PublicKey, "key-1")).ParseSecEvent(signed); err != nil {
+		t.Errorf("ParseSecEvent() error = %v", err)
+	}
+}
+
+func TestLimits_CheckJSONMalformed(t *testing.T) {
+	if err := DefaultLimits.checkJSON([]byte(`{"events":{`)); err == nil {
+		t.Error("expected error for truncated JSON")
+	}
+}
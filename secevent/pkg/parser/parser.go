@@ -2,24 +2,56 @@ package parser
 
 import (
 	"context"
+	"crypto/subtle"
 	"fmt"
+	"net/http"
 	"net/url"
+	"sort"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/lestrrat-go/jwx/v2/jwk"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/replay"
 	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
 
 	_ "github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep" // Initialize CAEP events
+	_ "github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/risc" // Initialize RISC events
 	_ "github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/ssf"  // Initialize SSF events
 )
 
 // Parser parses and validates SecEvents
 type Parser struct {
-	keySet           jwk.Set
-	jwksURL          *url.URL
-	expectedIssuer   string
-	expectedAudience []string
+	keySet               jwk.Set
+	jwksURL              *url.URL
+	jwksCache            *jwksCache
+	httpClient           *http.Client
+	jwksCacheTTL         time.Duration
+	jwksRefreshInterval  time.Duration
+	expectedIssuer       string
+	expectedAudience     []string
+	decryptionKeys       []interface{}
+	trustStore           *TrustStore
+	allowedAlgorithms    []string
+	jtiStore             replay.JTIStore
+	maxTokenAge          time.Duration
+	maxEventAge          time.Duration
+	clockSkew            time.Duration
+	rejectFutureIssuedAt bool
+	now                  func() time.Time
+	lenientEvents        bool
+	eventRegistry        *event.Registry
+	legacySubject        bool
+	strictProfile        bool
+	limits               Limits
+}
+
+// DefaultAllowedAlgorithms are the asymmetric JWS algorithms accepted when no allowlist is configured
+var DefaultAllowedAlgorithms = []string{
+	"RS256", "RS384", "RS512",
+	"PS256", "PS384", "PS512",
+	"ES256", "ES384", "ES512",
+	"EdDSA",
 }
 
 // Option defines the function signature for parser options
@@ -73,6 +105,36 @@ func WithPublicKey(key interface{}, kid string) Option {
 	}
 }
 
+// WithHTTPClient sets the HTTP client used to fetch the JWKS
+func WithHTTPClient(client *http.Client) Option {
+	return func(p *Parser) {
+		p.httpClient = client
+	}
+}
+
+// WithJWKSCacheTTL sets how long a fetched JWKS is cached when the response carries no Cache-Control max-age
+func WithJWKSCacheTTL(ttl time.Duration) Option {
+	return func(p *Parser) {
+		p.jwksCacheTTL = ttl
+	}
+}
+
+// WithJWKSRefreshInterval sets the minimum time between JWKS fetches, which rate limits
+// refreshes triggered by unknown key IDs and retries after a failed fetch
+func WithJWKSRefreshInterval(interval time.Duration) Option {
+	return func(p *Parser) {
+		p.jwksRefreshInterval = interval
+	}
+}
+
+// WithAllowedAlgorithms restricts the JWS algorithms accepted for signature verification.
+// Defaults to DefaultAllowedAlgorithms.
+func WithAllowedAlgorithms(algorithms ...string) Option {
+	return func(p *Parser) {
+		p.allowedAlgorithms = algorithms
+	}
+}
+
 // WithExpectedIssuer sets the expected issuer for validation
 func WithExpectedIssuer(issuer string) Option {
 	return func(p *Parser) {
@@ -80,108 +142,315 @@ func WithExpectedIssuer(issuer string) Option {
 	}
 }
 
-// WithExpectedAudience sets the expected audience for validation
+// WithExpectedAudience sets the expected audience for validation. When several are given, a
+// token must be addressed to at least one of them.
 func WithExpectedAudience(audience ...string) Option {
 	return func(p *Parser) {
 		p.expectedAudience = audience
 	}
 }
 
+// WithJTIStore enables replay protection: each verified SecEvent's (iss, jti) pair is recorded
+// in the store, and a token whose pair was already recorded is rejected with a *replay.ReplayError
+func WithJTIStore(store replay.JTIStore) Option {
+	return func(p *Parser) {
+		p.jtiStore = store
+	}
+}
+
+// WithLenientEvents decodes events whose type has no registered parser as *event.RawEvent,
+// preserving their original JSON, instead of rejecting the token
+func WithLenientEvents() Option {
+	return func(p *Parser) {
+		p.lenientEvents = true
+	}
+}
+
+// WithEventRegistry sets the registry used to resolve event parsers.
+// Defaults to event.DefaultRegistry.
+func WithEventRegistry(registry *event.Registry) Option {
+	return func(p *Parser) {
+		p.eventRegistry = registry
+	}
+}
+
+// WithLegacySubject accepts tokens in the layout of early CAEP drafts, which carry the subject
+// inside each event ("subject", with subject_type instead of format) rather than in sub_id.
+// The event-level subject is lifted to sub_id; tokens whose events name different subjects
+// are rejected. Legacy tokens lack sub_id, so they fail WithStrictProfile.
+func WithLegacySubject() Option {
+	return func(p *Parser) {
+		p.legacySubject = true
+	}
+}
+
 // NewParser creates a new SecEvent parser with the provided options
 func NewParser(opts ...Option) *Parser {
-	p := &Parser{}
+	p := &Parser{now: time.Now}
 	for _, opt := range opts {
 		opt(p)
 	}
 
+	if p.jwksURL != nil {
+		p.jwksCache = newJWKSCache(p.jwksURL, p.httpClient, p.jwksCacheTTL, p.jwksRefreshInterval)
+	}
+
 	return p
 }
 
-// fetchJWKS fetches the JWKS from the configured URL
-func (p *Parser) fetchJWKS() (jwk.Set, error) {
-	if p.jwksURL == nil {
-		return nil, fmt.Errorf("no JWKS URL configured")
-	}
+// keySource looks up verification keys by key ID
+type keySource interface {
+	lookupKey(ctx context.Context, kid string) (jwk.Key, error)
+}
 
-	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
-	defer cancel()
+// staticKeySource serves keys from a fixed key set
+type staticKeySource struct {
+	keySet jwk.Set
+}
 
-	keySet, err := jwk.Fetch(ctx, p.jwksURL.String())
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch JWKS: %w", err)
+func (s *staticKeySource) lookupKey(_ context.Context, kid string) (jwk.Key, error) {
+	key, found := s.keySet.LookupKeyID(kid)
+	if !found {
+		return nil, fmt.Errorf("no key found for kid %s", kid)
 	}
 
-	return keySet, nil
+	return key, nil
 }
 
-// getKey returns the appropriate key for JWT verification
-func (p *Parser) getKey(token *jwt.Token) (interface{}, error) {
-	kid, ok := token.Header["kid"].(string)
-	if !ok {
-		return nil, fmt.Errorf("token header does not contain 'kid'")
+// keySource returns the parser-wide source of verification keys
+func (p *Parser) keySource() (keySource, error) {
+	if p.jwksCache != nil {
+		return p.jwksCache, nil
 	}
 
-	var keySet jwk.Set
-	var err error
+	if p.keySet != nil {
+		return &staticKeySource{keySet: p.keySet}, nil
+	}
 
-	// If we have a JWKS URL, fetch fresh keys
-	if p.jwksURL != nil {
-		keySet, err = p.fetchJWKS()
+	return nil, fmt.Errorf("no keys available for verification")
+}
+
+// keyFunc returns a jwt.Keyfunc resolving the token's kid against the given key source
+func keyFunc(source keySource) jwt.Keyfunc {
+	return func(token *jwt.Token) (interface{}, error) {
+		kid, ok := token.Header["kid"].(string)
+		if !ok {
+			return nil, fmt.Errorf("token header does not contain 'kid'")
+		}
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		key, err := source.lookupKey(ctx, kid)
 		if err != nil {
 			return nil, err
 		}
-	} else if p.keySet != nil {
-		keySet = p.keySet
-	} else {
-		return nil, fmt.Errorf("no keys available for verification")
-	}
 
-	key, found := keySet.LookupKeyID(kid)
-	if !found {
-		return nil, fmt.Errorf("no key found for kid %s", kid)
-	}
+		var rawKey interface{}
+		if err := key.Raw(&rawKey); err != nil {
+			return nil, fmt.Errorf("failed to get raw key: %w", err)
+		}
 
-	var rawKey interface{}
-	if err := key.Raw(&rawKey); err != nil {
-		return nil, fmt.Errorf("failed to get raw key: %w", err)
+		return rawKey, nil
 	}
-
-	return rawKey, nil
 }
 
 // getParserOptions returns the appropriate JWT parser options based on configuration
 func (p *Parser) getParserOptions() []jwt.ParserOption {
+	return buildParserOptions(p.algorithms(), p.expectedIssuer)
+}
+
+// algorithms returns the parser-wide algorithm allowlist
+func (p *Parser) algorithms() []string {
+	if len(p.allowedAlgorithms) > 0 {
+		return p.allowedAlgorithms
+	}
+
+	return DefaultAllowedAlgorithms
+}
+
+// buildParserOptions returns the JWT parser options for the given algorithms and issuer.
+// Audiences are checked by checkAudience, since jwt.WithAudience accepts only one.
+func buildParserOptions(algorithms []string, issuer string) []jwt.ParserOption {
 	options := []jwt.ParserOption{
-		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithValidMethods(algorithms),
 	}
 
-	if p.expectedIssuer != "" {
-		options = append(options, jwt.WithIssuer(p.expectedIssuer))
+	if issuer != "" {
+		options = append(options, jwt.WithIssuer(issuer))
 	}
 
-	// Handle audience validation
-	if len(p.expectedAudience) > 0 {
-		for _, aud := range p.expectedAudience {
-			options = append(options, jwt.WithAudience(aud))
+	return options
+}
+
+// checkAudience requires the token's aud claim to contain at least one of the expected
+// audiences. Nothing is checked when no audience is expected.
+func checkAudience(claims jwt.Claims, expected []string) error {
+	if len(expected) == 0 {
+		return nil
+	}
+
+	audience, err := claims.GetAudience()
+	if err != nil {
+		return err
+	}
+
+	if len(audience) == 0 {
+		return fmt.Errorf("%w: aud claim is required", jwt.ErrTokenRequiredClaimMissing)
+	}
+
+	// Compare every pair in constant time, as jwt.WithAudience does
+	match := 0
+	for _, aud := range audience {
+		for _, want := range expected {
+			match |= subtle.ConstantTimeCompare([]byte(aud), []byte(want))
 		}
 	}
 
-	return options
+	if match == 0 {
+		return jwt.ErrTokenInvalidAudience
+	}
+
+	return nil
+}
+
+// verificationConfig selects the key source, validation options and expected audiences for a
+// token, consulting the trust store (if configured) based on the token's unverified issuer
+func (p *Parser) verificationConfig(tokenString string) (jwt.Keyfunc, []jwt.ParserOption, []string, error) {
+	if p.trustStore == nil {
+		source, err := p.keySource()
+		if err != nil {
+			return nil, nil, nil, err
+		}
+
+		return keyFunc(source), p.getParserOptions(), p.expectedAudience, nil
+	}
+
+	issuer, err := unverifiedIssuer(tokenString)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	trust, ok := p.trustStore.lookup(issuer)
+	if !ok {
+		return nil, nil, nil, fmt.Errorf("issuer %q is not trusted", issuer)
+	}
+
+	algorithms := trust.algorithms
+	if len(algorithms) == 0 {
+		algorithms = p.algorithms()
+	}
+
+	audience := trust.audience
+	if len(audience) == 0 {
+		audience = p.expectedAudience
+	}
+
+	return keyFunc(trust.keys), buildParserOptions(algorithms, trust.issuer), audience, nil
+}
+
+// decodeOptions returns the options used to decode token claims
+func (p *Parser) decodeOptions() token.DecodeOptions {
+	return token.DecodeOptions{
+		Registry:      p.eventRegistry,
+		LenientEvents: p.lenientEvents,
+		LegacySubject: p.legacySubject,
+	}
+}
+
+// preflight enforces the parser's limits, decrypts the token if needed and checks the SET
+// profile in strict mode. It runs before any key lookup.
+func (p *Parser) preflight(tokenString string) (string, error) {
+	if err := p.limits.checkTokenSize(tokenString); err != nil {
+		return "", err
+	}
+
+	tokenString, err := p.decrypt(tokenString)
+	if err != nil {
+		return "", err
+	}
+
+	if err := p.limits.checkSignedToken(tokenString); err != nil {
+		return "", err
+	}
+
+	if p.strictProfile {
+		if err := checkProfile(tokenString); err != nil {
+			return "", err
+		}
+	}
+
+	return tokenString, nil
+}
+
+// parseVerified decrypts (if needed), verifies and validates the token into claims
+func (p *Parser) parseVerified(tokenString string, claims jwt.Claims) error {
+	tokenString, err := p.preflight(tokenString)
+	if err != nil {
+		return err
+	}
+
+	keyFunc, options, audience, err := p.verificationConfig(tokenString)
+	if err != nil {
+		return fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	parser := jwt.NewParser(options...)
+
+	token, err := parser.ParseWithClaims(tokenString, claims, keyFunc)
+	if err != nil {
+		return fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	if !token.Valid {
+		return fmt.Errorf("invalid token")
+	}
+
+	if err := checkAudience(claims, audience); err != nil {
+		return fmt.Errorf("failed to parse token: %w", err)
+	}
+
+	return nil
+}
+
+// checkReplay records the token's (iss, jti) pair when replay protection is enabled
+func (p *Parser) checkReplay(issuer, jti string) error {
+	if p.jtiStore == nil {
+		return nil
+	}
+
+	return replay.Check(p.jtiStore, issuer, jti)
 }
 
 // ParseMultiSecEvent parses and validates a signed SecEvent
 func (p *Parser) ParseMultiSecEvent(tokenString string) (*token.MultiSecEvent, error) {
 	var set token.MultiSecEvent
 
-	parser := jwt.NewParser(p.getParserOptions()...)
+	set.WithDecodeOptions(p.decodeOptions())
 
-	token, err := parser.ParseWithClaims(tokenString, &set, p.getKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %w", err)
+	if err := p.parseVerified(tokenString, &set); err != nil {
+		return nil, err
 	}
 
-	if !token.Valid {
-		return nil, fmt.Errorf("invalid token")
+	eventTypes := make([]event.EventType, 0, len(set.Events))
+	for eventType := range set.Events {
+		eventTypes = append(eventTypes, eventType)
+	}
+
+	// Check events in a stable order so the reported stale event does not vary between runs
+	sort.Slice(eventTypes, func(i, j int) bool { return eventTypes[i] < eventTypes[j] })
+
+	events := make([]event.Event, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		events = append(events, set.Events[eventType])
+	}
+
+	if err := p.checkFreshness(set.IssuedAt, events...); err != nil {
+		return nil, err
+	}
+
+	if err := p.checkReplay(set.Issuer, set.ID); err != nil {
+		return nil, err
 	}
 
 	return &set, nil
@@ -191,15 +460,18 @@ func (p *Parser) ParseMultiSecEvent(tokenString string) (*token.MultiSecEvent, e
 func (p *Parser) ParseSecEvent(tokenString string) (*token.SecEvent, error) {
 	var set token.SecEvent
 
-	parser := jwt.NewParser(p.getParserOptions()...)
+	set.WithDecodeOptions(p.decodeOptions())
 
-	token, err := parser.ParseWithClaims(tokenString, &set, p.getKey)
-	if err != nil {
-		return nil, fmt.Errorf("failed to parse token: %w", err)
+	if err := p.parseVerified(tokenString, &set); err != nil {
+		return nil, err
 	}
 
-	if !token.Valid {
-		return nil, fmt.Errorf("invalid token")
+	if err := p.checkFreshness(set.IssuedAt, set.Event); err != nil {
+		return nil, err
+	}
+
+	if err := p.checkReplay(set.Issuer, set.ID); err != nil {
+		return nil, err
 	}
 
 	return &set, nil
@@ -209,9 +481,16 @@ func (p *Parser) ParseSecEvent(tokenString string) (*token.SecEvent, error) {
 func (p *Parser) ParseMultiSecEventNoVerify(tokenString string) (*token.MultiSecEvent, error) {
 	var set token.MultiSecEvent
 
+	set.WithDecodeOptions(p.decodeOptions())
+
+	tokenString, err := p.preflight(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
 	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
 
-	_, _, err := parser.ParseUnverified(tokenString, &set)
+	_, _, err = parser.ParseUnverified(tokenString, &set)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
@@ -223,9 +502,16 @@ func (p *Parser) ParseMultiSecEventNoVerify(tokenString string) (*token.MultiSec
 func (p *Parser) ParseSecEventNoVerify(tokenString string) (*token.SecEvent, error) {
 	var set token.SecEvent
 
+	set.WithDecodeOptions(p.decodeOptions())
+
+	tokenString, err := p.preflight(tokenString)
+	if err != nil {
+		return nil, err
+	}
+
 	parser := jwt.NewParser(jwt.WithoutClaimsValidation())
 
-	_, _, err := parser.ParseUnverified(tokenString, &set)
+	_, _, err = parser.ParseUnverified(tokenString, &set)
 	if err != nil {
 		return nil, fmt.Errorf("failed to parse token: %w", err)
 	}
@@ -0,0 +1,142 @@
+package parser
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+// SecEventMediaType is the JWT typ required by the SET profile (RFC 8417 section 2.3)
+const SecEventMediaType = "secevent+jwt"
+
+// ProfileError is returned when a token does not conform to the RFC 8417 / SSF SET profile.
+// It lists every violation, so a single parse reports all conformance problems of a transmitter.
+type ProfileError struct {
+	Violations []*event.EventError
+}
+
+// Error returns the string representation of the error
+func (e *ProfileError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Error()
+	}
+
+	return fmt.Sprintf("token does not conform to the SET profile: %s", strings.Join(messages, "; "))
+}
+
+// Unwrap returns the violations so that errors.As can match an *event.EventError
+func (e *ProfileError) Unwrap() []error {
+	errs := make([]error, len(e.Violations))
+	for i, violation := range e.Violations {
+		errs[i] = violation
+	}
+
+	return errs
+}
+
+// WithStrictProfile rejects tokens that do not conform to the SET profile of RFC 8417 and
+// the Shared Signals Framework, before the signature is verified:
+//
+//   - the typ header must be secevent+jwt and no crit header may be present
+//   - iss, iat, jti, events and sub_id are required, and events must be a non-empty object
+//   - the sub and exp claims must not be present
+//
+// Violations are reported as a *ProfileError.
+func WithStrictProfile() Option {
+	return func(p *Parser) {
+		p.strictProfile = true
+	}
+}
+
+// CheckProfile decrypts the token if needed and checks it against the SET profile without
+// verifying its signature. It returns a *ProfileError if the token does not conform, which is
+// useful to run conformance checks against a transmitter.
+func (p *Parser) CheckProfile(tokenString string) error {
+	tokenString, err := p.decrypt(tokenString)
+	if err != nil {
+		return err
+	}
+
+	return checkProfile(tokenString)
+}
+
+func checkProfile(tokenString string) error {
+	claims := jwt.MapClaims{}
+
+	tok, _, err := jwt.NewParser().ParseUnverified(tokenString, claims)
+	if err != nil {
+		return &ProfileError{Violations: []*event.EventError{
+			profileViolation(event.ErrCodeParseError, "token is not a well-formed JWS", "", err.Error()),
+		}}
+	}
+
+	var violations []*event.EventError
+
+	switch typ, ok := tok.Header["typ"].(string); {
+	case !ok:
+		violations = append(violations, profileViolation(event.ErrCodeMissingField,
+			"typ header is required", "typ", ""))
+	case !isSecEventType(typ):
+		violations = append(violations, profileViolation(event.ErrCodeInvalidValue,
+			"typ header must be "+SecEventMediaType, "typ", typ))
+	}
+
+	if _, ok := tok.Header["crit"]; ok {
+		violations = append(violations, profileViolation(event.ErrCodeInvalidValue,
+			"critical header parameters are not supported", "crit", ""))
+	}
+
+	for _, claim := range []string{"iss", "iat", "jti", "sub_id"} {
+		if _, ok := claims[claim]; !ok {
+			violations = append(violations, profileViolation(event.ErrCodeMissingField,
+				claim+" claim is required", claim, ""))
+		}
+	}
+
+	switch events, ok := claims["events"]; {
+	case !ok:
+		violations = append(violations, profileViolation(event.ErrCodeMissingField,
+			"events claim is required", "events", ""))
+	default:
+		if eventsObject, isObject := events.(map[string]interface{}); !isObject || len(eventsObject) == 0 {
+			violations = append(violations, profileViolation(event.ErrCodeInvalidValue,
+				"events claim must be a non-empty JSON object", "events", ""))
+		}
+	}
+
+	if _, ok := claims["sub"]; ok {
+		violations = append(violations, profileViolation(event.ErrCodeInvalidValue,
+			"sub claim must not be present; use sub_id", "sub", ""))
+	}
+
+	if _, ok := claims["exp"]; ok {
+		violations = append(violations, profileViolation(event.ErrCodeInvalidValue,
+			"exp claim must not be present", "exp", ""))
+	}
+
+	if len(violations) > 0 {
+		return &ProfileError{Violations: violations}
+	}
+
+	return nil
+}
+
+// isSecEventType compares a typ header against secevent+jwt; media types are case-insensitive
+// and the application/ prefix may be omitted (RFC 7515 section 4.1.9)
+func isSecEventType(typ string) bool {
+	typ = strings.ToLower(typ)
+
+	return typ == SecEventMediaType || typ == "application/"+SecEventMediaType
+}
+
+func profileViolation(code event.ErrorCode, message, field, details string) *event.EventError {
+	return &event.EventError{
+		Code:    code,
+		Message: message,
+		Field:   field,
+		Details: details,
+	}
+}
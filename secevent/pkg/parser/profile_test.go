@@ -0,0 +1,169 @@
+package parser
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"sort"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+)
+
+func signProfileTestToken(t *testing.T, key *ecdsa.PrivateKey, header map[string]interface{}, claims jwt.MapClaims) string {
+	t.Helper()
+
+	tok := jwt.NewWithClaims(jwt.SigningMethodES256, claims)
+	tok.Header["kid"] = "key-1"
+	delete(tok.Header, "typ")
+
+	for name, value := range header {
+		tok.Header[name] = value
+	}
+
+	signed, err := tok.SignedString(key)
+	if err != nil {
+		t.Fatalf("SignedString() error = %v", err)
+	}
+
+	return signed
+}
+
+func conformingClaims() jwt.MapClaims {
+	return jwt.MapClaims{
+		"iss":    "https://issuer.example.com",
+		"jti":    "profile-1",
+		"iat":    1700000000,
+		"sub_id": map[string]interface{}{"format": "email", "email": "user@example.com"},
+		"events": map[string]interface{}{string(caep.EventTypeSessionRevoked): map[string]interface{}{}},
+	}
+}
+
+func TestParser_StrictProfile(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	secEventTyp := map[string]interface{}{"typ": "secevent+jwt"}
+
+	tests := []struct {
+		name       string
+		header     map[string]interface{}
+		claims     func(jwt.MapClaims)
+		wantFields []string
+	}{
+		{
+			name:   "conforming token",
+			header: secEventTyp,
+		},
+		{
+			name:   "media type with application prefix",
+			header: map[string]interface{}{"typ": "application/SecEvent+JWT"},
+		},
+		{
+			name:       "missing typ",
+			wantFields: []string{"typ"},
+		},
+		{
+			name:       "wrong typ and crit header",
+			header:     map[string]interface{}{"typ": "JWT", "crit": []string{"exp"}},
+			wantFields: []string{"crit", "typ"},
+		},
+		{
+			name:   "sub and exp claims",
+			header: secEventTyp,
+			claims: func(c jwt.MapClaims) {
+				c["sub"] = "user-123"
+				c["exp"] = 1800000000
+			},
+			wantFields: []string{"exp", "sub"},
+		},
+		{
+			name:   "missing required claims",
+			header: secEventTyp,
+			claims: func(c jwt.MapClaims) {
+				delete(c, "iat")
+				delete(c, "sub_id")
+				c["events"] = map[string]interface{}{}
+			},
+			wantFields: []string{"events", "iat", "sub_id"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			claims := conformingClaims()
+			if tt.claims != nil {
+				tt.claims(claims)
+			}
+
+			signed := signProfileTestToken(t, key, tt.header, claims)
+			p := NewParser(WithPublicKey(&key.PublicKey, "key-1"), WithStrictProfile())
+
+			err := p.CheckProfile(signed)
+
+			if len(tt.wantFields) == 0 {
+				if err != nil {
+					t.Fatalf("CheckProfile() error = %v", err)
+				}
+
+				if _, err := p.ParseSecEvent(signed); err != nil {
+					t.Errorf("ParseSecEvent() error = %v", err)
+				}
+
+				return
+			}
+
+			var profileErr *ProfileError
+			if !errors.As(err, &profileErr) {
+				t.Fatalf("CheckProfile() error = %v, want *ProfileError", err)
+			}
+
+			fields := make([]string, 0, len(profileErr.Violations))
+			for _, violation := range profileErr.Violations {
+				fields = append(fields, violation.Field)
+			}
+
+			sort.Strings(fields)
+
+			if len(fields) != len(tt.wantFields) {
+				t.Fatalf("violations = %v, want %v", fields, tt.wantFields)
+			}
+
+			for i := range fields {
+				if fields[i] != tt.wantFields[i] {
+					t.Errorf("violations = %v, want %v", fields, tt.wantFields)
+				}
+			}
+
+			if _, err := p.ParseMultiSecEventNoVerify(signed); !errors.As(err, &profileErr) {
+				t.Errorf("ParseMultiSecEventNoVerify() error = %v, want *ProfileError", err)
+			}
+
+			var eventErr *event.EventError
+			if _, err := p.ParseSecEvent(signed); !errors.As(err, &eventErr) {
+				t.Errorf("ParseSecEvent() error = %v, want *event.EventError", err)
+			}
+		})
+	}
+}
+
+func TestParser_StrictProfileDisabled(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	claims := conformingClaims()
+	claims["sub"] = "user-123"
+
+	signed := signProfileTestToken(t, key, nil, claims)
+
+	if _, err := NewParser(WithPublicKey(&key.PublicKey, "key-1")).ParseSecEvent(signed); err != nil {
+		t.Errorf("ParseSecEvent() without strict profile error = %v", err)
+	}
+}
@@ -0,0 +1,44 @@
+package parser
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/ssf"
+)
+
+func TestParser_EventRegistry(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	// signTestSecEvent carries a CAEP session revoked event
+	signed := signTestSecEvent(t, key, "key-1", "https://issuer.example.com", "https://receiver.example.com")
+
+	ssfOnly := event.NewRegistry()
+	ssf.RegisterEvents(ssfOnly)
+
+	p := NewParser(WithPublicKey(&key.PublicKey, "key-1"), WithEventRegistry(ssfOnly))
+	if _, err := p.ParseSecEvent(signed); err == nil {
+		t.Error("expected error for event type missing from the parser's registry")
+	}
+
+	caepOnly := event.NewRegistry()
+	caep.RegisterEvents(caepOnly)
+
+	p = NewParser(WithPublicKey(&key.PublicKey, "key-1"), WithEventRegistry(caepOnly))
+
+	set, err := p.ParseSecEvent(signed)
+	if err != nil {
+		t.Fatalf("ParseSecEvent() error = %v", err)
+	}
+
+	if set.Event.Type() != caep.EventTypeSessionRevoked {
+		t.Errorf("Event.Type() = %s", set.Event.Type())
+	}
+}
@@ -0,0 +1,51 @@
+package parser
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/replay"
+)
+
+func TestParser_ReplayProtection(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	p := NewParser(
+		WithPublicKey(&key.PublicKey, "key-1"),
+		WithJTIStore(replay.NewMemoryStore()),
+	)
+
+	first := signTestSecEvent(t, key, "key-1", "https://issuer.example.com", "https://receiver.example.com")
+
+	if _, err := p.ParseSecEvent(first); err != nil {
+		t.Fatalf("ParseSecEvent() error = %v", err)
+	}
+
+	_, err = p.ParseSecEvent(first)
+	if !replay.IsReplay(err) {
+		t.Fatalf("expected replay error, got %v", err)
+	}
+
+	second := signTestSecEvent(t, key, "key-1", "https://issuer.example.com", "https://receiver.example.com")
+
+	if _, err := p.ParseMultiSecEvent(second); err != nil {
+		t.Errorf("ParseMultiSecEvent() error = %v", err)
+	}
+
+	// Tokens that fail verification are not recorded
+	other, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	forged := signTestSecEvent(t, other, "key-1", "https://issuer.example.com", "https://receiver.example.com")
+
+	if _, err := p.ParseSecEvent(forged); err == nil || replay.IsReplay(err) {
+		t.Errorf("expected signature error, got %v", err)
+	}
+}
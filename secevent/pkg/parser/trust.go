@@ -0,0 +1,228 @@
+package parser
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// IssuerTrust describes how SecEvents from a single issuer are verified.
+// Exactly one of JWKSURL, JWKSJSON or PublicKeys must be set.
+type IssuerTrust struct {
+	// Issuer is the iss claim value of the transmitter
+	Issuer string
+
+	// JWKSURL is the transmitter's JWKS endpoint; fetched keys are cached
+	JWKSURL string
+
+	// JWKSJSON is a static JWKS document
+	JWKSJSON []byte
+
+	// PublicKeys maps key IDs to static public keys
+	PublicKeys map[string]interface{}
+
+	// Algorithms restricts the signing algorithms accepted from this issuer.
+	// Defaults to the parser's algorithm allowlist.
+	Algorithms []string
+
+	// Audience lists the audiences accepted for tokens from this issuer; a token must be
+	// addressed to at least one of them. Defaults to the parser's expected audience.
+	Audience []string
+}
+
+// issuerTrust is the resolved form of an IssuerTrust
+type issuerTrust struct {
+	issuer     string
+	keys       keySource
+	algorithms []string
+	audience   []string
+}
+
+// TrustStore maps issuers to their verification configuration. It is safe for concurrent use.
+type TrustStore struct {
+	httpClient          *http.Client
+	jwksCacheTTL        time.Duration
+	jwksRefreshInterval time.Duration
+
+	mu      sync.RWMutex
+	issuers map[string]*issuerTrust
+}
+
+// TrustStoreOption defines the function signature for trust store options
+type TrustStoreOption func(*TrustStore)
+
+// WithTrustStoreHTTPClient sets the HTTP client used to fetch issuer JWKS
+func WithTrustStoreHTTPClient(client *http.Client) TrustStoreOption {
+	return func(s *TrustStore) {
+		s.httpClient = client
+	}
+}
+
+// WithTrustStoreJWKSCacheTTL sets the JWKS cache TTL used for issuers configured with a JWKS URL
+func WithTrustStoreJWKSCacheTTL(ttl time.Duration) TrustStoreOption {
+	return func(s *TrustStore) {
+		s.jwksCacheTTL = ttl
+	}
+}
+
+// WithTrustStoreJWKSRefreshInterval sets the minimum time between JWKS fetches for each issuer
+func WithTrustStoreJWKSRefreshInterval(interval time.Duration) TrustStoreOption {
+	return func(s *TrustStore) {
+		s.jwksRefreshInterval = interval
+	}
+}
+
+// NewTrustStore creates an empty trust store with the provided options
+func NewTrustStore(opts ...TrustStoreOption) *TrustStore {
+	s := &TrustStore{
+		issuers: make(map[string]*issuerTrust),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// AddIssuer adds or replaces the trust configuration for an issuer
+func (s *TrustStore) AddIssuer(trust IssuerTrust) error {
+	if trust.Issuer == "" {
+		return fmt.Errorf("issuer is required")
+	}
+
+	keys, err := s.keySource(trust)
+	if err != nil {
+		return fmt.Errorf("invalid trust configuration for issuer %q: %w", trust.Issuer, err)
+	}
+
+	resolved := &issuerTrust{
+		issuer:     trust.Issuer,
+		keys:       keys,
+		algorithms: append([]string(nil), trust.Algorithms...),
+		audience:   append([]string(nil), trust.Audience...),
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.issuers[trust.Issuer] = resolved
+
+	return nil
+}
+
+// RemoveIssuer removes the trust configuration for an issuer
+func (s *TrustStore) RemoveIssuer(issuer string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.issuers, issuer)
+}
+
+// Issuers returns the configured issuers in sorted order
+func (s *TrustStore) Issuers() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	issuers := make([]string, 0, len(s.issuers))
+	for issuer := range s.issuers {
+		issuers = append(issuers, issuer)
+	}
+
+	sort.Strings(issuers)
+
+	return issuers
+}
+
+func (s *TrustStore) lookup(issuer string) (*issuerTrust, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	trust, ok := s.issuers[issuer]
+
+	return trust, ok
+}
+
+func (s *TrustStore) keySource(trust IssuerTrust) (keySource, error) {
+	configured := 0
+	if trust.JWKSURL != "" {
+		configured++
+	}
+
+	if len(trust.JWKSJSON) > 0 {
+		configured++
+	}
+
+	if len(trust.PublicKeys) > 0 {
+		configured++
+	}
+
+	if configured != 1 {
+		return nil, fmt.Errorf("exactly one of JWKS URL, JWKS JSON or public keys must be set")
+	}
+
+	switch {
+	case trust.JWKSURL != "":
+		jwksURL, err := url.Parse(trust.JWKSURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS URL: %w", err)
+		}
+
+		return newJWKSCache(jwksURL, s.httpClient, s.jwksCacheTTL, s.jwksRefreshInterval), nil
+	case len(trust.JWKSJSON) > 0:
+		keySet, err := jwk.Parse(trust.JWKSJSON)
+		if err != nil {
+			return nil, fmt.Errorf("invalid JWKS JSON: %w", err)
+		}
+
+		return &staticKeySource{keySet: keySet}, nil
+	default:
+		keySet := jwk.NewSet()
+		for kid, publicKey := range trust.PublicKeys {
+			key, err := jwk.FromRaw(publicKey)
+			if err != nil {
+				return nil, fmt.Errorf("invalid public key %q: %w", kid, err)
+			}
+
+			if err := key.Set(jwk.KeyIDKey, kid); err != nil {
+				return nil, fmt.Errorf("failed to set key ID %q: %w", kid, err)
+			}
+
+			if err := keySet.AddKey(key); err != nil {
+				return nil, fmt.Errorf("failed to add public key %q: %w", kid, err)
+			}
+		}
+
+		return &staticKeySource{keySet: keySet}, nil
+	}
+}
+
+// WithTrustStore verifies each SecEvent against the trust configuration of its issuer.
+// Tokens from issuers that are not in the store are rejected. When a trust store is
+// configured, the parser-wide key options and expected issuer are not used.
+func WithTrustStore(store *TrustStore) Option {
+	return func(p *Parser) {
+		p.trustStore = store
+	}
+}
+
+// unverifiedIssuer reads the iss claim from a token without verifying its signature
+func unverifiedIssuer(tokenString string) (string, error) {
+	var claims jwt.RegisteredClaims
+
+	if _, _, err := jwt.NewParser().ParseUnverified(tokenString, &claims); err != nil {
+		return "", fmt.Errorf("failed to read token issuer: %w", err)
+	}
+
+	if claims.Issuer == "" {
+		return "", fmt.Errorf("token does not contain an issuer")
+	}
+
+	return claims.Issuer, nil
+}
@@ -0,0 +1,177 @@
+package parser
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"errors"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/signing"
+)
+
+func signTestSecEvent(t *testing.T, key *ecdsa.PrivateKey, kid, issuer, audience string) string {
+	t.Helper()
+
+	signer, err := signing.NewSigner(key, signing.WithKeyID(kid))
+	if err != nil {
+		t.Fatalf("NewSigner() error = %v", err)
+	}
+
+	secEvent := newTestSecEvent(t).WithIssuer(issuer).WithAudience(audience)
+
+	signed, err := signer.Sign(secEvent)
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	return signed
+}
+
+func TestParser_TrustStore(t *testing.T) {
+	keyA, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	keyB, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	store := NewTrustStore()
+
+	if err := store.AddIssuer(IssuerTrust{
+		Issuer:     "https://a.example.com",
+		PublicKeys: map[string]interface{}{"a-1": &keyA.PublicKey},
+		Audience:   []string{"https://receiver-a.example.com", "https://receiver-a2.example.com"},
+	}); err != nil {
+		t.Fatalf("AddIssuer() error = %v", err)
+	}
+
+	if err := store.AddIssuer(IssuerTrust{
+		Issuer:     "https://b.example.com",
+		PublicKeys: map[string]interface{}{"b-1": &keyB.PublicKey},
+		Algorithms: []string{"ES256"},
+	}); err != nil {
+		t.Fatalf("AddIssuer() error = %v", err)
+	}
+
+	p := NewParser(WithTrustStore(store))
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "issuer A with its own key",
+			token: signTestSecEvent(t, keyA, "a-1", "https://a.example.com", "https://receiver-a.example.com"),
+		},
+		{
+			name:  "issuer A to its second audience",
+			token: signTestSecEvent(t, keyA, "a-1", "https://a.example.com", "https://receiver-a2.example.com"),
+		},
+		{
+			name:  "issuer B with its own key",
+			token: signTestSecEvent(t, keyB, "b-1", "https://b.example.com", "https://anyone.example.com"),
+		},
+		{
+			name:    "issuer A with wrong audience",
+			token:   signTestSecEvent(t, keyA, "a-1", "https://a.example.com", "https://receiver-b.example.com"),
+			wantErr: true,
+		},
+		{
+			name:    "issuer A claiming a key of issuer B",
+			token:   signTestSecEvent(t, keyB, "b-1", "https://a.example.com", "https://receiver-a.example.com"),
+			wantErr: true,
+		},
+		{
+			name:    "unknown issuer",
+			token:   signTestSecEvent(t, keyA, "a-1", "https://evil.example.com", "https://receiver-a.example.com"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.ParseSecEvent(tt.token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseSecEvent() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParser_ExpectedAudiences(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	p := NewParser(
+		WithPublicKey(&key.PublicKey, "key-1"),
+		WithExpectedAudience("https://receiver-1.example.com", "https://receiver-2.example.com"),
+	)
+
+	tests := []struct {
+		audience string
+		wantErr  error
+	}{
+		{audience: "https://receiver-1.example.com"},
+		{audience: "https://receiver-2.example.com"},
+		{audience: "https://receiver-3.example.com", wantErr: jwt.ErrTokenInvalidAudience},
+		{audience: "", wantErr: jwt.ErrTokenRequiredClaimMissing},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.audience, func(t *testing.T) {
+			signer, err := signing.NewSigner(key, signing.WithKeyID("key-1"))
+			if err != nil {
+				t.Fatalf("NewSigner() error = %v", err)
+			}
+
+			secEvent := newTestSecEvent(t)
+			secEvent.Audience = nil
+
+			if tt.audience != "" {
+				secEvent.WithAudience(tt.audience)
+			}
+
+			signed, err := signer.Sign(secEvent)
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			_, err = p.ParseSecEvent(signed)
+			if tt.wantErr == nil && err != nil {
+				t.Errorf("ParseSecEvent() error = %v", err)
+			}
+
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ParseSecEvent() error = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestTrustStore_AddIssuerValidation(t *testing.T) {
+	store := NewTrustStore()
+
+	if err := store.AddIssuer(IssuerTrust{Issuer: "https://a.example.com"}); err == nil {
+		t.Error("expected error when no key source is configured")
+	}
+
+	if err := store.AddIssuer(IssuerTrust{
+		Issuer:   "https://a.example.com",
+		JWKSURL:  "https://a.example.com/jwks.json",
+		JWKSJSON: []byte(`{"keys":[]}`),
+	}); err == nil {
+		t.Error("expected error when multiple key sources are configured")
+	}
+
+	if err := store.AddIssuer(IssuerTrust{JWKSURL: "https://a.example.com/jwks.json"}); err == nil {
+		t.Error("expected error when issuer is missing")
+	}
+}
@@ -0,0 +1,261 @@
+package replay
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// autoCompactMinAppends is the minimum number of appends between two automatic compactions
+const autoCompactMinAppends = 1024
+
+// FileStore is a JTIStore that persists pairs to an append-only file so replay protection
+// survives restarts. Expired entries are dropped when the file is opened, on Compact, and
+// automatically once the appends since the last compaction outnumber the entries it kept
+// (at least 1024), which bounds the file to about twice its live size. Errors from automatic
+// compaction are not reported; call Compact to observe them.
+// The file must not be shared between processes.
+type FileStore struct {
+	path   string
+	ttl    time.Duration
+	now    func() time.Time
+	rename func(oldpath, newpath string) error
+
+	mu      sync.Mutex
+	file    *os.File
+	entries map[key]time.Time
+
+	// appended counts records written since the last compaction, which kept compacted entries
+	appended  int
+	compacted int
+}
+
+type fileRecord struct {
+	Issuer    string `json:"iss"`
+	JTI       string `json:"jti"`
+	ExpiresAt int64  `json:"exp"`
+}
+
+// FileStoreOption configures a FileStore
+type FileStoreOption func(*FileStore)
+
+// WithFileTTL sets how long a (iss, jti) pair is remembered
+func WithFileTTL(ttl time.Duration) FileStoreOption {
+	return func(s *FileStore) {
+		if ttl > 0 {
+			s.ttl = ttl
+		}
+	}
+}
+
+// NewFileStore opens (or creates) the store at path, loading the unexpired entries it contains
+func NewFileStore(path string, opts ...FileStoreOption) (*FileStore, error) {
+	s := &FileStore{
+		path:    path,
+		ttl:     DefaultTTL,
+		now:     time.Now,
+		rename:  os.Rename,
+		entries: make(map[key]time.Time),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	if err := s.load(); err != nil {
+		return nil, err
+	}
+
+	if err := s.Compact(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// CheckAndStore implements JTIStore
+func (s *FileStore) CheckAndStore(issuer, jti string) (bool, error) {
+	k := key{issuer: issuer, jti: jti}
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return false, fmt.Errorf("file store is closed")
+	}
+
+	if expiresAt, ok := s.entries[k]; ok && now.Before(expiresAt) {
+		return true, nil
+	}
+
+	expiresAt := now.Add(s.ttl)
+
+	if err := s.append(fileRecord{Issuer: issuer, JTI: jti, ExpiresAt: expiresAt.Unix()}); err != nil {
+		return false, err
+	}
+
+	s.entries[k] = expiresAt
+	s.appended++
+
+	if s.appended >= max(autoCompactMinAppends, s.compacted) {
+		// On failure the store keeps appending to the current file; retry after as many appends
+		if err := s.compact(now); err != nil {
+			s.appended = 0
+		}
+	}
+
+	return false, nil
+}
+
+// Compact rewrites the file with only the unexpired entries. If compaction fails the store
+// keeps using the original file.
+func (s *FileStore) Compact() error {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.compact(now)
+}
+
+// compact implements Compact. Must be called with mu held.
+func (s *FileStore) compact(now time.Time) error {
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".tmp*")
+	if err != nil {
+		return fmt.Errorf("failed to create temporary file: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	writer := bufio.NewWriter(tmp)
+	encoder := json.NewEncoder(writer)
+	kept := 0
+
+	for k, expiresAt := range s.entries {
+		if !now.Before(expiresAt) {
+			delete(s.entries, k)
+
+			continue
+		}
+
+		if err := encoder.Encode(fileRecord{Issuer: k.issuer, JTI: k.jti, ExpiresAt: expiresAt.Unix()}); err != nil {
+			tmp.Close()
+
+			return fmt.Errorf("failed to write entry: %w", err)
+		}
+
+		kept++
+	}
+
+	if err := writer.Flush(); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("failed to write entries: %w", err)
+	}
+
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+
+		return fmt.Errorf("failed to sync file: %w", err)
+	}
+
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("failed to close file: %w", err)
+	}
+
+	if s.file != nil {
+		s.file.Close()
+		s.file = nil
+	}
+
+	// Whether or not the rename succeeds, s.path holds a complete store file to reopen
+	renameErr := s.rename(tmp.Name(), s.path)
+
+	file, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("failed to open store file: %w", err)
+	}
+
+	s.file = file
+
+	if renameErr != nil {
+		return fmt.Errorf("failed to replace store file: %w", renameErr)
+	}
+
+	s.appended = 0
+	s.compacted = kept
+
+	return nil
+}
+
+// Close closes the underlying file
+func (s *FileStore) Close() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.file == nil {
+		return nil
+	}
+
+	err := s.file.Close()
+	s.file = nil
+
+	return err
+}
+
+func (s *FileStore) load() error {
+	file, err := os.Open(s.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	if err != nil {
+		return fmt.Errorf("failed to open store file: %w", err)
+	}
+	defer file.Close()
+
+	// Lines are read whole, whatever their length, so one long record cannot make the file
+	// unreadable
+	reader := bufio.NewReader(file)
+
+	for {
+		line, err := reader.ReadBytes('\n')
+		if len(line) > 0 {
+			var record fileRecord
+			if jsonErr := json.Unmarshal(line, &record); jsonErr == nil {
+				s.entries[key{issuer: record.Issuer, jti: record.JTI}] = time.Unix(record.ExpiresAt, 0)
+			}
+			// Otherwise the line is skipped, e.g. a torn final line from an interrupted write
+		}
+
+		if err == io.EOF {
+			return nil
+		}
+
+		if err != nil {
+			return fmt.Errorf("failed to read store file: %w", err)
+		}
+	}
+}
+
+func (s *FileStore) append(record fileRecord) error {
+	line, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("failed to encode entry: %w", err)
+	}
+
+	if _, err := s.file.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("failed to write entry: %w", err)
+	}
+
+	if err := s.file.Sync(); err != nil {
+		return fmt.Errorf("failed to sync store file: %w", err)
+	}
+
+	return nil
+}
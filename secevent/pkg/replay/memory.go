@@ -0,0 +1,118 @@
+package replay
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// DefaultCapacity is the maximum number of entries held by a MemoryStore when no capacity is configured
+const DefaultCapacity = 100000
+
+// MemoryStore is an in-memory JTIStore bounded by both a TTL and a maximum number of entries.
+// When full, the least recently seen entry is evicted, so the capacity should comfortably
+// exceed the number of SecEvents expected within one TTL.
+type MemoryStore struct {
+	ttl      time.Duration
+	capacity int
+	now      func() time.Time
+
+	mu      sync.Mutex
+	entries map[key]*list.Element
+	lru     *list.List
+}
+
+type memoryEntry struct {
+	key       key
+	expiresAt time.Time
+}
+
+// MemoryStoreOption configures a MemoryStore
+type MemoryStoreOption func(*MemoryStore)
+
+// WithTTL sets how long a (iss, jti) pair is remembered
+func WithTTL(ttl time.Duration) MemoryStoreOption {
+	return func(s *MemoryStore) {
+		if ttl > 0 {
+			s.ttl = ttl
+		}
+	}
+}
+
+// WithCapacity sets the maximum number of remembered pairs
+func WithCapacity(capacity int) MemoryStoreOption {
+	return func(s *MemoryStore) {
+		if capacity > 0 {
+			s.capacity = capacity
+		}
+	}
+}
+
+// NewMemoryStore creates an empty in-memory store
+func NewMemoryStore(opts ...MemoryStoreOption) *MemoryStore {
+	s := &MemoryStore{
+		ttl:      DefaultTTL,
+		capacity: DefaultCapacity,
+		now:      time.Now,
+		entries:  make(map[key]*list.Element),
+		lru:      list.New(),
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// CheckAndStore implements JTIStore
+func (s *MemoryStore) CheckAndStore(issuer, jti string) (bool, error) {
+	k := key{issuer: issuer, jti: jti}
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if elem, ok := s.entries[k]; ok {
+		entry := elem.Value.(*memoryEntry)
+		if now.Before(entry.expiresAt) {
+			s.lru.MoveToFront(elem)
+
+			return true, nil
+		}
+
+		// Expired; treat as new and refresh its lifetime
+		entry.expiresAt = now.Add(s.ttl)
+		s.lru.MoveToFront(elem)
+
+		return false, nil
+	}
+
+	s.evict(now)
+
+	elem := s.lru.PushFront(&memoryEntry{key: k, expiresAt: now.Add(s.ttl)})
+	s.entries[k] = elem
+
+	return false, nil
+}
+
+// Len returns the number of remembered pairs, including any not yet evicted after expiring
+func (s *MemoryStore) Len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.lru.Len()
+}
+
+// evict drops expired entries from the tail and, if still full, the least recently seen entry
+func (s *MemoryStore) evict(now time.Time) {
+	for elem := s.lru.Back(); elem != nil; elem = s.lru.Back() {
+		entry := elem.Value.(*memoryEntry)
+		if now.Before(entry.expiresAt) && s.lru.Len() < s.capacity {
+			return
+		}
+
+		s.lru.Remove(elem)
+		delete(s.entries, entry.key)
+	}
+}
@@ -0,0 +1,61 @@
+// Package replay provides stores that remember the JWT IDs of received SecEvents so that a
+// token delivered more than once can be rejected.
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// DefaultTTL is how long a (iss, jti) pair is remembered when no TTL is configured
+const DefaultTTL = 24 * time.Hour
+
+// JTIStore records the (iss, jti) pairs of accepted SecEvents.
+// Implementations must be safe for concurrent use.
+type JTIStore interface {
+	// CheckAndStore atomically records the (issuer, jti) pair and reports whether it had
+	// already been recorded and not yet expired
+	CheckAndStore(issuer, jti string) (seen bool, err error)
+}
+
+// ReplayError is returned when a SecEvent with an already seen (iss, jti) pair is parsed
+type ReplayError struct {
+	Issuer string
+	JTI    string
+}
+
+func (e *ReplayError) Error() string {
+	return fmt.Sprintf("replayed SecEvent: jti %q from issuer %q has already been received", e.JTI, e.Issuer)
+}
+
+// IsReplay reports whether err is, or wraps, a *ReplayError
+func IsReplay(err error) bool {
+	var replayErr *ReplayError
+
+	return errors.As(err, &replayErr)
+}
+
+// Check records the pair in the store and returns a *ReplayError if it had already been seen
+func Check(store JTIStore, issuer, jti string) error {
+	if jti == "" {
+		return fmt.Errorf("JWT ID (jti) claim is required for replay protection")
+	}
+
+	seen, err := store.CheckAndStore(issuer, jti)
+	if err != nil {
+		return fmt.Errorf("failed to record jti: %w", err)
+	}
+
+	if seen {
+		return &ReplayError{Issuer: issuer, JTI: jti}
+	}
+
+	return nil
+}
+
+// key identifies a SecEvent by issuer and JWT ID
+type key struct {
+	issuer string
+	jti    string
+}
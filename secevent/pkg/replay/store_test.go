@@ -0,0 +1,252 @@
+package replay
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestMemoryStore(t *testing.T) {
+	clock := time.Unix(1700000000, 0)
+
+	s := NewMemoryStore(WithTTL(time.Minute), WithCapacity(2))
+	s.now = func() time.Time { return clock }
+
+	if seen, _ := s.CheckAndStore("iss-a", "1"); seen {
+		t.Error("first delivery reported as seen")
+	}
+
+	if seen, _ := s.CheckAndStore("iss-a", "1"); !seen {
+		t.Error("second delivery not reported as seen")
+	}
+
+	// The same jti from another issuer is a different token
+	if seen, _ := s.CheckAndStore("iss-b", "1"); seen {
+		t.Error("jti from a different issuer reported as seen")
+	}
+
+	// Capacity is 2, so the least recently seen entry (iss-a) is evicted
+	if seen, _ := s.CheckAndStore("iss-a", "2"); seen {
+		t.Error("new jti reported as seen")
+	}
+
+	if s.Len() != 2 {
+		t.Errorf("Len() = %d, want 2", s.Len())
+	}
+
+	clock = clock.Add(2 * time.Minute)
+
+	if seen, _ := s.CheckAndStore("iss-a", "2"); seen {
+		t.Error("expired entry reported as seen")
+	}
+}
+
+func TestMemoryStore_Concurrent(t *testing.T) {
+	s := NewMemoryStore()
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex
+		fresh int
+	)
+
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			if seen, _ := s.CheckAndStore("iss", "same"); !seen {
+				mu.Lock()
+				fresh++
+				mu.Unlock()
+			}
+		}()
+	}
+
+	wg.Wait()
+
+	if fresh != 1 {
+		t.Errorf("%d goroutines saw the jti as new, want 1", fresh)
+	}
+}
+
+func TestFileStore_PersistsAcrossRestarts(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jti.log")
+	clock := time.Now() // NewFileStore compacts against the wall clock
+	now := func() time.Time { return clock }
+
+	s, err := NewFileStore(path, WithFileTTL(time.Hour))
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	s.now = now
+
+	for i := 0; i < 3; i++ {
+		if seen, err := s.CheckAndStore("iss", fmt.Sprint(i)); err != nil || seen {
+			t.Fatalf("CheckAndStore() = %v, %v", seen, err)
+		}
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reopened, err := NewFileStore(path, WithFileTTL(time.Hour))
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	defer reopened.Close()
+
+	reopened.now = now
+
+	if seen, _ := reopened.CheckAndStore("iss", "1"); !seen {
+		t.Error("jti recorded before restart not reported as seen")
+	}
+
+	clock = clock.Add(2 * time.Hour)
+
+	if err := reopened.Compact(); err != nil {
+		t.Fatalf("Compact() error = %v", err)
+	}
+
+	if len(reopened.entries) != 0 {
+		t.Errorf("expected expired entries to be compacted, %d remain", len(reopened.entries))
+	}
+
+	if seen, _ := reopened.CheckAndStore("iss", "1"); seen {
+		t.Error("expired jti reported as seen")
+	}
+}
+
+func TestFileStore_LongLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jti.log")
+	longJTI := strings.Repeat("j", 200*1024)
+	expiresAt := time.Now().Add(time.Hour).Unix()
+
+	content := fmt.Sprintf("{\"iss\":\"iss\",\"jti\":%q,\"exp\":%d}\n", longJTI, expiresAt) +
+		strings.Repeat("x", 100*1024) + "\n" +
+		fmt.Sprintf("{\"iss\":\"iss\",\"jti\":\"short\",\"exp\":%d}\n", expiresAt)
+
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	s, err := NewFileStore(path, WithFileTTL(time.Hour))
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	defer s.Close()
+
+	for _, jti := range []string{longJTI, "short"} {
+		if seen, err := s.CheckAndStore("iss", jti); err != nil || !seen {
+			t.Errorf("CheckAndStore() = %v, %v; want jti of length %d reported as seen", seen, err, len(jti))
+		}
+	}
+}
+
+func TestFileStore_AutoCompaction(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jti.log")
+	clock := time.Now()
+
+	s, err := NewFileStore(path, WithFileTTL(time.Hour))
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	defer s.Close()
+
+	s.now = func() time.Time { return clock }
+
+	for i := 0; i < autoCompactMinAppends-10; i++ {
+		if _, err := s.CheckAndStore("iss", fmt.Sprint("old-", i)); err != nil {
+			t.Fatalf("CheckAndStore() error = %v", err)
+		}
+	}
+
+	clock = clock.Add(2 * time.Hour)
+
+	for i := 0; i < 10; i++ {
+		if _, err := s.CheckAndStore("iss", fmt.Sprint("new-", i)); err != nil {
+			t.Fatalf("CheckAndStore() error = %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if lines := strings.Count(string(data), "\n"); lines != 10 {
+		t.Errorf("store file has %d lines after automatic compaction, want 10", lines)
+	}
+
+	if len(s.entries) != 10 {
+		t.Errorf("%d entries remain after automatic compaction, want 10", len(s.entries))
+	}
+}
+
+func TestFileStore_CompactFailureKeepsStoreOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "jti.log")
+
+	s, err := NewFileStore(path, WithFileTTL(time.Hour))
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+
+	if _, err := s.CheckAndStore("iss", "before"); err != nil {
+		t.Fatalf("CheckAndStore() error = %v", err)
+	}
+
+	s.rename = func(string, string) error { return errors.New("disk full") }
+
+	if err := s.Compact(); err == nil {
+		t.Fatal("Compact() error = nil, want rename failure")
+	}
+
+	if _, err := s.CheckAndStore("iss", "after"); err != nil {
+		t.Fatalf("CheckAndStore() after failed compaction error = %v", err)
+	}
+
+	if err := s.Close(); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+
+	reopened, err := NewFileStore(path, WithFileTTL(time.Hour))
+	if err != nil {
+		t.Fatalf("NewFileStore() error = %v", err)
+	}
+	defer reopened.Close()
+
+	for _, jti := range []string{"before", "after"} {
+		if seen, _ := reopened.CheckAndStore("iss", jti); !seen {
+			t.Errorf("jti %q not persisted across a failed compaction", jti)
+		}
+	}
+}
+
+func TestCheck(t *testing.T) {
+	s := NewMemoryStore()
+
+	if err := Check(s, "iss", ""); err == nil {
+		t.Error("expected error for empty jti")
+	}
+
+	if err := Check(s, "iss", "1"); err != nil {
+		t.Fatalf("Check() error = %v", err)
+	}
+
+	err := Check(s, "iss", "1")
+	if !IsReplay(err) {
+		t.Fatalf("expected replay error, got %v", err)
+	}
+
+	if replayErr := err.(*ReplayError); replayErr.Issuer != "iss" || replayErr.JTI != "1" {
+		t.Errorf("unexpected replay error fields: %+v", replayErr)
+	}
+}
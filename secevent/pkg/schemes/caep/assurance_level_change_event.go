@@ -140,7 +140,7 @@ func (e *AssuranceLevelChangeEvent) Payload() interface{} {
 }
 
 func (e *AssuranceLevelChangeEvent) MarshalJSON() ([]byte, error) {
-	return json.Marshal(e.Payload())
+	return event.MarshalPayload(e)
 }
 
 func (e *AssuranceLevelChangeEvent) UnmarshalJSON(data []byte) error {
@@ -159,6 +159,10 @@ func (e *AssuranceLevelChangeEvent) UnmarshalJSON(data []byte) error {
 	e.AssuranceLevelChangePayload = payload.AssuranceLevelChangePayload
 	e.Metadata = payload.EventMetadata
 
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
 	return e.Validate()
 }
 
@@ -173,5 +177,5 @@ func ParseAssuranceLevelChangeEvent(data []byte) (event.Event, error) {
 }
 
 func init() {
-	event.RegisterEventParser(EventTypeAssuranceLevelChange, ParseAssuranceLevelChangeEvent)
+	register(EventTypeAssuranceLevelChange, ParseAssuranceLevelChangeEvent)
 }
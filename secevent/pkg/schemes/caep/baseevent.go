@@ -144,7 +144,7 @@ func (e *BaseCAEPEvent) Payload() interface{} {
 }
 
 func (e *BaseCAEPEvent) MarshalJSON() ([]byte, error) {
-	return json.Marshal(e.Payload())
+	return event.MarshalPayload(e)
 }
 
 func (e *BaseCAEPEvent) UnmarshalJSON(data []byte) error {
@@ -159,5 +159,9 @@ func (e *BaseCAEPEvent) UnmarshalJSON(data []byte) error {
 
 	e.Metadata = aux.Metadata
 
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
 	return nil
 }
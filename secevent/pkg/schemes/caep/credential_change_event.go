@@ -55,7 +55,7 @@ func NewCredentialChangeEvent(credType CredentialType, changeType ChangeType) *C
 		},
 	}
 
-	e.SetType(EventTypeAssuranceLevelChange)
+	e.SetType(EventTypeCredentialChange)
 
 	return e
 }
@@ -171,7 +171,7 @@ func (e *CredentialChangeEvent) Payload() interface{} {
 }
 
 func (e *CredentialChangeEvent) MarshalJSON() ([]byte, error) {
-	return json.Marshal(e.Payload())
+	return event.MarshalPayload(e)
 }
 
 func (e *CredentialChangeEvent) UnmarshalJSON(data []byte) error {
@@ -190,6 +190,10 @@ func (e *CredentialChangeEvent) UnmarshalJSON(data []byte) error {
 	e.CredentialChangePayload = payload.CredentialChangePayload
 	e.Metadata = payload.EventMetadata
 
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
 	return e.Validate()
 }
 
@@ -227,5 +231,5 @@ func ParseCredentialChangeEvent(data []byte) (event.Event, error) {
 }
 
 func init() {
-	event.RegisterEventParser(EventTypeCredentialChange, ParseCredentialChangeEvent)
+	register(EventTypeCredentialChange, ParseCredentialChangeEvent)
 }
@@ -0,0 +1,30 @@
+package caep
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+func TestNewCredentialChangeEvent_Type(t *testing.T) {
+	evt := NewCredentialChangeEvent(CredentialTypePassword, ChangeTypeUpdate)
+
+	if evt.Type() != EventTypeCredentialChange {
+		t.Fatalf("Type() = %s, want %s", evt.Type(), EventTypeCredentialChange)
+	}
+
+	data, err := json.Marshal(evt)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	parsed, err := event.ParseEvent(evt.Type(), data)
+	if err != nil {
+		t.Fatalf("ParseEvent() error = %v", err)
+	}
+
+	if _, ok := parsed.(*CredentialChangeEvent); !ok {
+		t.Errorf("ParseEvent() returned %T, want *CredentialChangeEvent", parsed)
+	}
+}
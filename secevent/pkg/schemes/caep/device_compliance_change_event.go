@@ -88,7 +88,7 @@ func (e *DeviceComplianceChangeEvent) Payload() interface{} {
 }
 
 func (e *DeviceComplianceChangeEvent) MarshalJSON() ([]byte, error) {
-	return json.Marshal(e.Payload())
+	return event.MarshalPayload(e)
 }
 
 func (e *DeviceComplianceChangeEvent) UnmarshalJSON(data []byte) error {
@@ -107,6 +107,10 @@ func (e *DeviceComplianceChangeEvent) UnmarshalJSON(data []byte) error {
 	e.DeviceComplianceChangePayload = payload.DeviceComplianceChangePayload
 	e.Metadata = payload.EventMetadata
 
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
 	return e.Validate()
 }
 
@@ -153,5 +157,5 @@ func ParseDeviceComplianceChangeEvent(data []byte) (event.Event, error) {
 }
 
 func init() {
-	event.RegisterEventParser(EventTypeDeviceComplianceChange, ParseDeviceComplianceChangeEvent)
+	register(EventTypeDeviceComplianceChange, ParseDeviceComplianceChangeEvent)
 }
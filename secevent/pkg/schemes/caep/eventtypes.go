@@ -20,6 +20,15 @@ const (
 
 	// EventTypeDeviceComplianceChange event type indicates a change in device compliance status
 	EventTypeDeviceComplianceChange event.EventType = "https://schemas.openid.net/secevent/caep/event-type/device-compliance-change"
+
+	// EventTypeSessionEstablished event type indicates that a new session has been established
+	EventTypeSessionEstablished event.EventType = "https://schemas.openid.net/secevent/caep/event-type/session-established"
+
+	// EventTypeSessionPresented event type indicates that an existing session has been presented to a service
+	EventTypeSessionPresented event.EventType = "https://schemas.openid.net/secevent/caep/event-type/session-presented"
+
+	// EventTypeRiskLevelChange event type indicates a change in the risk level of a principal
+	EventTypeRiskLevelChange event.EventType = "https://schemas.openid.net/secevent/caep/event-type/risk-level-change"
 )
 
 // IsCaepEventType checks if the given event type is a valid CAEP event type
@@ -29,7 +38,10 @@ func IsCaepEventType(eventType event.EventType) bool {
 		EventTypeSessionRevoked,
 		EventTypeCredentialChange,
 		EventTypeAssuranceLevelChange,
-		EventTypeDeviceComplianceChange:
+		EventTypeDeviceComplianceChange,
+		EventTypeSessionEstablished,
+		EventTypeSessionPresented,
+		EventTypeRiskLevelChange:
 		return true
 	default:
 		return false
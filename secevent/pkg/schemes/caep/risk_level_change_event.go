@@ -0,0 +1,214 @@
+package caep
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+// RiskLevel represents the risk level of a principal
+type RiskLevel string
+
+const (
+	RiskLevelLow    RiskLevel = "LOW"
+	RiskLevelMedium RiskLevel = "MEDIUM"
+	RiskLevelHigh   RiskLevel = "HIGH"
+)
+
+// RiskPrincipal represents the type of principal whose risk level changed
+type RiskPrincipal string
+
+const (
+	RiskPrincipalUser    RiskPrincipal = "USER"
+	RiskPrincipalDevice  RiskPrincipal = "DEVICE"
+	RiskPrincipalSession RiskPrincipal = "SESSION"
+	RiskPrincipalTenant  RiskPrincipal = "TENANT"
+	RiskPrincipalOrgUnit RiskPrincipal = "ORG_UNIT"
+	RiskPrincipalGroup   RiskPrincipal = "GROUP"
+)
+
+type RiskLevelChangePayload struct {
+	RiskReason    string        `json:"risk_reason"`              // REQUIRED
+	Principal     RiskPrincipal `json:"principal"`                // REQUIRED
+	CurrentLevel  RiskLevel     `json:"current_level"`            // REQUIRED
+	PreviousLevel *RiskLevel    `json:"previous_level,omitempty"` // OPTIONAL
+}
+
+type RiskLevelChangeEvent struct {
+	BaseCAEPEvent
+	RiskLevelChangePayload
+}
+
+func NewRiskLevelChangeEvent(riskReason string, principal RiskPrincipal, currentLevel RiskLevel) *RiskLevelChangeEvent {
+	e := &RiskLevelChangeEvent{
+		RiskLevelChangePayload: RiskLevelChangePayload{
+			RiskReason:   riskReason,
+			Principal:    principal,
+			CurrentLevel: currentLevel,
+		},
+	}
+
+	e.SetType(EventTypeRiskLevelChange)
+
+	return e
+}
+
+func (e *RiskLevelChangeEvent) WithPreviousLevel(level RiskLevel) *RiskLevelChangeEvent {
+	e.PreviousLevel = &level
+
+	return e
+}
+
+func (e *RiskLevelChangeEvent) WithEventTimestamp(timestamp int64) *RiskLevelChangeEvent {
+	e.BaseCAEPEvent.WithEventTimestamp(timestamp)
+
+	return e
+}
+
+func (e *RiskLevelChangeEvent) WithInitiatingEntity(entity InitiatingEntity) *RiskLevelChangeEvent {
+	e.BaseCAEPEvent.WithInitiatingEntity(entity)
+
+	return e
+}
+
+func (e *RiskLevelChangeEvent) WithReasonAdmin(language, reason string) *RiskLevelChangeEvent {
+	e.BaseCAEPEvent.WithReasonAdmin(language, reason)
+
+	return e
+}
+
+func (e *RiskLevelChangeEvent) WithReasonUser(language, reason string) *RiskLevelChangeEvent {
+	e.BaseCAEPEvent.WithReasonUser(language, reason)
+
+	return e
+}
+
+func (e *RiskLevelChangeEvent) GetRiskReason() string {
+	return e.RiskReason
+}
+
+func (e *RiskLevelChangeEvent) GetPrincipal() RiskPrincipal {
+	return e.Principal
+}
+
+func (e *RiskLevelChangeEvent) GetCurrentLevel() RiskLevel {
+	return e.CurrentLevel
+}
+
+func (e *RiskLevelChangeEvent) GetPreviousLevel() (RiskLevel, bool) {
+	if e.PreviousLevel == nil {
+		return "", false
+	}
+
+	return *e.PreviousLevel, true
+}
+
+func (e *RiskLevelChangeEvent) Validate() error {
+	if err := e.ValidateMetadata(); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(e.RiskReason) == "" {
+		return event.NewError(event.ErrCodeMissingValue,
+			"risk reason is required",
+			"risk_reason", "")
+	}
+
+	if !IsValidRiskPrincipal(e.Principal) {
+		return event.NewError(event.ErrCodeInvalidValue,
+			fmt.Sprintf("invalid principal: %s", e.Principal),
+			"principal", "")
+	}
+
+	if !IsValidRiskLevel(e.CurrentLevel) {
+		return event.NewError(event.ErrCodeInvalidValue,
+			fmt.Sprintf("invalid current level: %s", e.CurrentLevel),
+			"current_level", "")
+	}
+
+	if e.PreviousLevel != nil && !IsValidRiskLevel(*e.PreviousLevel) {
+		return event.NewError(event.ErrCodeInvalidValue,
+			fmt.Sprintf("invalid previous level: %s", *e.PreviousLevel),
+			"previous_level", "")
+	}
+
+	return nil
+}
+
+func (e *RiskLevelChangeEvent) Payload() interface{} {
+	payload := e.RiskLevelChangePayload
+
+	if e.Metadata != nil {
+		return struct {
+			RiskLevelChangePayload
+			*EventMetadata
+		}{
+			RiskLevelChangePayload: payload,
+			EventMetadata:          e.Metadata,
+		}
+	}
+
+	return payload
+}
+
+func (e *RiskLevelChangeEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *RiskLevelChangeEvent) UnmarshalJSON(data []byte) error {
+	var payload struct {
+		RiskLevelChangePayload
+		*EventMetadata
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse risk level change event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeRiskLevelChange)
+
+	e.RiskLevelChangePayload = payload.RiskLevelChangePayload
+	e.Metadata = payload.EventMetadata
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func IsValidRiskLevel(level RiskLevel) bool {
+	switch level {
+	case RiskLevelLow, RiskLevelMedium, RiskLevelHigh:
+		return true
+	default:
+		return false
+	}
+}
+
+func IsValidRiskPrincipal(principal RiskPrincipal) bool {
+	switch principal {
+	case RiskPrincipalUser, RiskPrincipalDevice, RiskPrincipalSession,
+		RiskPrincipalTenant, RiskPrincipalOrgUnit, RiskPrincipalGroup:
+		return true
+	default:
+		return false
+	}
+}
+
+func ParseRiskLevelChangeEvent(data []byte) (event.Event, error) {
+	var e RiskLevelChangeEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse risk level change event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeRiskLevelChange, ParseRiskLevelChangeEvent)
+}
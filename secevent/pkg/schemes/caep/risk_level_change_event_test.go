@@ -0,0 +1,76 @@
+package caep
+
+import (
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+func TestParseRiskLevelChangeEvent(t *testing.T) {
+	data := []byte(`{
+		"risk_reason": "PASSWORD_FOUND_IN_DATA_BREACH",
+		"principal": "USER",
+		"current_level": "HIGH",
+		"previous_level": "LOW",
+		"event_timestamp": 1615304991643
+	}`)
+
+	parsed, err := event.ParseEvent(EventTypeRiskLevelChange, data)
+	if err != nil {
+		t.Fatalf("ParseEvent() error = %v", err)
+	}
+
+	riskEvent, ok := parsed.(*RiskLevelChangeEvent)
+	if !ok {
+		t.Fatalf("ParseEvent() returned %T, want *RiskLevelChangeEvent", parsed)
+	}
+
+	if riskEvent.GetPrincipal() != RiskPrincipalUser {
+		t.Errorf("GetPrincipal() = %v, want %v", riskEvent.GetPrincipal(), RiskPrincipalUser)
+	}
+
+	if previous, ok := riskEvent.GetPreviousLevel(); !ok || previous != RiskLevelLow {
+		t.Errorf("GetPreviousLevel() = %v, %v; want %v, true", previous, ok, RiskLevelLow)
+	}
+
+	if ts, ok := riskEvent.GetEventTimestamp(); !ok || ts != 1615304991643 {
+		t.Errorf("GetEventTimestamp() = %v, %v", ts, ok)
+	}
+}
+
+func TestRiskLevelChangeEvent_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   *RiskLevelChangeEvent
+		wantErr bool
+	}{
+		{
+			name:  "valid",
+			event: NewRiskLevelChangeEvent("reason", RiskPrincipalDevice, RiskLevelMedium),
+		},
+		{
+			name:    "missing reason",
+			event:   NewRiskLevelChangeEvent("", RiskPrincipalDevice, RiskLevelMedium),
+			wantErr: true,
+		},
+		{
+			name:    "invalid principal",
+			event:   NewRiskLevelChangeEvent("reason", "ROBOT", RiskLevelMedium),
+			wantErr: true,
+		},
+		{
+			name:    "invalid previous level",
+			event:   NewRiskLevelChangeEvent("reason", RiskPrincipalUser, RiskLevelHigh).WithPreviousLevel("SEVERE"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.event.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,176 @@
+package caep
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type SessionEstablishedPayload struct {
+	FingerprintUserAgent *string  `json:"fp_ua,omitempty"`  // OPTIONAL
+	ACR                  *string  `json:"acr,omitempty"`    // OPTIONAL
+	AMR                  []string `json:"amr,omitempty"`    // OPTIONAL
+	ExternalSessionID    *string  `json:"ext_id,omitempty"` // OPTIONAL
+}
+
+type SessionEstablishedEvent struct {
+	BaseCAEPEvent
+	SessionEstablishedPayload
+}
+
+func NewSessionEstablishedEvent() *SessionEstablishedEvent {
+	e := &SessionEstablishedEvent{}
+
+	e.SetType(EventTypeSessionEstablished)
+
+	return e
+}
+
+func (e *SessionEstablishedEvent) WithFingerprintUserAgent(fingerprint string) *SessionEstablishedEvent {
+	e.FingerprintUserAgent = &fingerprint
+
+	return e
+}
+
+func (e *SessionEstablishedEvent) WithACR(acr string) *SessionEstablishedEvent {
+	e.ACR = &acr
+
+	return e
+}
+
+func (e *SessionEstablishedEvent) WithAMR(amr ...string) *SessionEstablishedEvent {
+	e.AMR = append(e.AMR, amr...)
+
+	return e
+}
+
+func (e *SessionEstablishedEvent) WithExternalSessionID(extID string) *SessionEstablishedEvent {
+	e.ExternalSessionID = &extID
+
+	return e
+}
+
+func (e *SessionEstablishedEvent) WithEventTimestamp(timestamp int64) *SessionEstablishedEvent {
+	e.BaseCAEPEvent.WithEventTimestamp(timestamp)
+
+	return e
+}
+
+func (e *SessionEstablishedEvent) WithInitiatingEntity(entity InitiatingEntity) *SessionEstablishedEvent {
+	e.BaseCAEPEvent.WithInitiatingEntity(entity)
+
+	return e
+}
+
+func (e *SessionEstablishedEvent) WithReasonAdmin(language, reason string) *SessionEstablishedEvent {
+	e.BaseCAEPEvent.WithReasonAdmin(language, reason)
+
+	return e
+}
+
+func (e *SessionEstablishedEvent) WithReasonUser(language, reason string) *SessionEstablishedEvent {
+	e.BaseCAEPEvent.WithReasonUser(language, reason)
+
+	return e
+}
+
+func (e *SessionEstablishedEvent) GetFingerprintUserAgent() (string, bool) {
+	if e.FingerprintUserAgent == nil {
+		return "", false
+	}
+
+	return *e.FingerprintUserAgent, true
+}
+
+func (e *SessionEstablishedEvent) GetACR() (string, bool) {
+	if e.ACR == nil {
+		return "", false
+	}
+
+	return *e.ACR, true
+}
+
+func (e *SessionEstablishedEvent) GetAMR() []string {
+	return e.AMR
+}
+
+func (e *SessionEstablishedEvent) GetExternalSessionID() (string, bool) {
+	if e.ExternalSessionID == nil {
+		return "", false
+	}
+
+	return *e.ExternalSessionID, true
+}
+
+func (e *SessionEstablishedEvent) Validate() error {
+	if err := e.ValidateMetadata(); err != nil {
+		return err
+	}
+
+	for _, method := range e.AMR {
+		if method == "" {
+			return event.NewError(event.ErrCodeInvalidValue,
+				"amr values cannot be empty",
+				"amr", "")
+		}
+	}
+
+	return nil
+}
+
+func (e *SessionEstablishedEvent) Payload() interface{} {
+	payload := e.SessionEstablishedPayload
+
+	if e.Metadata != nil {
+		return struct {
+			SessionEstablishedPayload
+			*EventMetadata
+		}{
+			SessionEstablishedPayload: payload,
+			EventMetadata:             e.Metadata,
+		}
+	}
+
+	return payload
+}
+
+func (e *SessionEstablishedEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *SessionEstablishedEvent) UnmarshalJSON(data []byte) error {
+	var payload struct {
+		SessionEstablishedPayload
+		*EventMetadata
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse session established event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeSessionEstablished)
+
+	e.SessionEstablishedPayload = payload.SessionEstablishedPayload
+	e.Metadata = payload.EventMetadata
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseSessionEstablishedEvent(data []byte) (event.Event, error) {
+	var e SessionEstablishedEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse session established event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeSessionEstablished, ParseSessionEstablishedEvent)
+}
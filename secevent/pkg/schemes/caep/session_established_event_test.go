@@ -0,0 +1,117 @@
+package caep
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+func TestSessionEstablishedEvent_RoundTrip(t *testing.T) {
+	original := NewSessionEstablishedEvent().
+		WithFingerprintUserAgent("abb0b6e7da81a42233f8f2b1a8ddb1b9a4c81611").
+		WithACR("AAL2").
+		WithAMR("otp", "pwd").
+		WithExternalSessionID("12505abe-b4a8-4bb5-b7b7-8a7f6b4ad7e6").
+		WithEventTimestamp(1615304991643).
+		WithInitiatingEntity(InitiatingEntityUser)
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	parsed, err := event.ParseEvent(EventTypeSessionEstablished, data)
+	if err != nil {
+		t.Fatalf("ParseEvent() error = %v", err)
+	}
+
+	established, ok := parsed.(*SessionEstablishedEvent)
+	if !ok {
+		t.Fatalf("ParseEvent() returned %T, want *SessionEstablishedEvent", parsed)
+	}
+
+	if fp, ok := established.GetFingerprintUserAgent(); !ok || fp != "abb0b6e7da81a42233f8f2b1a8ddb1b9a4c81611" {
+		t.Errorf("GetFingerprintUserAgent() = %v, %v", fp, ok)
+	}
+
+	if acr, ok := established.GetACR(); !ok || acr != "AAL2" {
+		t.Errorf("GetACR() = %v, %v", acr, ok)
+	}
+
+	if amr := established.GetAMR(); len(amr) != 2 || amr[0] != "otp" || amr[1] != "pwd" {
+		t.Errorf("GetAMR() = %v", amr)
+	}
+
+	if extID, ok := established.GetExternalSessionID(); !ok || extID != "12505abe-b4a8-4bb5-b7b7-8a7f6b4ad7e6" {
+		t.Errorf("GetExternalSessionID() = %v, %v", extID, ok)
+	}
+
+	if ts, ok := established.GetEventTimestamp(); !ok || ts != 1615304991643 {
+		t.Errorf("GetEventTimestamp() = %v, %v", ts, ok)
+	}
+
+	remarshaled, err := json.Marshal(established)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if string(remarshaled) != string(data) {
+		t.Errorf("round trip = %s, want %s", remarshaled, data)
+	}
+}
+
+func TestSessionEstablishedEvent_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   *SessionEstablishedEvent
+		wantErr bool
+	}{
+		{
+			name:  "no members",
+			event: NewSessionEstablishedEvent(),
+		},
+		{
+			name:  "all members",
+			event: NewSessionEstablishedEvent().WithACR("AAL2").WithAMR("pwd").WithFingerprintUserAgent("fp").WithExternalSessionID("ext"),
+		},
+		{
+			name:    "empty amr value",
+			event:   NewSessionEstablishedEvent().WithAMR("pwd", ""),
+			wantErr: true,
+		},
+		{
+			name:    "negative event timestamp",
+			event:   NewSessionEstablishedEvent().WithEventTimestamp(-1),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.event.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSessionEstablishedEvent_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"amr is not an array", `{"amr": "pwd"}`},
+		{"empty amr value", `{"amr": [""]}`},
+		{"negative event timestamp", `{"event_timestamp": -1}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := event.ParseEvent(EventTypeSessionEstablished, []byte(tt.data)); err == nil {
+				t.Error("ParseEvent() error = nil, want error")
+			}
+		})
+	}
+}
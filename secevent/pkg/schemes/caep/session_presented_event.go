@@ -0,0 +1,138 @@
+package caep
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type SessionPresentedPayload struct {
+	FingerprintUserAgent *string `json:"fp_ua,omitempty"`  // OPTIONAL
+	ExternalSessionID    *string `json:"ext_id,omitempty"` // OPTIONAL
+}
+
+type SessionPresentedEvent struct {
+	BaseCAEPEvent
+	SessionPresentedPayload
+}
+
+func NewSessionPresentedEvent() *SessionPresentedEvent {
+	e := &SessionPresentedEvent{}
+
+	e.SetType(EventTypeSessionPresented)
+
+	return e
+}
+
+func (e *SessionPresentedEvent) WithFingerprintUserAgent(fingerprint string) *SessionPresentedEvent {
+	e.FingerprintUserAgent = &fingerprint
+
+	return e
+}
+
+func (e *SessionPresentedEvent) WithExternalSessionID(extID string) *SessionPresentedEvent {
+	e.ExternalSessionID = &extID
+
+	return e
+}
+
+func (e *SessionPresentedEvent) WithEventTimestamp(timestamp int64) *SessionPresentedEvent {
+	e.BaseCAEPEvent.WithEventTimestamp(timestamp)
+
+	return e
+}
+
+func (e *SessionPresentedEvent) WithInitiatingEntity(entity InitiatingEntity) *SessionPresentedEvent {
+	e.BaseCAEPEvent.WithInitiatingEntity(entity)
+
+	return e
+}
+
+func (e *SessionPresentedEvent) WithReasonAdmin(language, reason string) *SessionPresentedEvent {
+	e.BaseCAEPEvent.WithReasonAdmin(language, reason)
+
+	return e
+}
+
+func (e *SessionPresentedEvent) WithReasonUser(language, reason string) *SessionPresentedEvent {
+	e.BaseCAEPEvent.WithReasonUser(language, reason)
+
+	return e
+}
+
+func (e *SessionPresentedEvent) GetFingerprintUserAgent() (string, bool) {
+	if e.FingerprintUserAgent == nil {
+		return "", false
+	}
+
+	return *e.FingerprintUserAgent, true
+}
+
+func (e *SessionPresentedEvent) GetExternalSessionID() (string, bool) {
+	if e.ExternalSessionID == nil {
+		return "", false
+	}
+
+	return *e.ExternalSessionID, true
+}
+
+func (e *SessionPresentedEvent) Validate() error {
+	return e.ValidateMetadata()
+}
+
+func (e *SessionPresentedEvent) Payload() interface{} {
+	payload := e.SessionPresentedPayload
+
+	if e.Metadata != nil {
+		return struct {
+			SessionPresentedPayload
+			*EventMetadata
+		}{
+			SessionPresentedPayload: payload,
+			EventMetadata:           e.Metadata,
+		}
+	}
+
+	return payload
+}
+
+func (e *SessionPresentedEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *SessionPresentedEvent) UnmarshalJSON(data []byte) error {
+	var payload struct {
+		SessionPresentedPayload
+		*EventMetadata
+	}
+
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse session presented event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeSessionPresented)
+
+	e.SessionPresentedPayload = payload.SessionPresentedPayload
+	e.Metadata = payload.EventMetadata
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseSessionPresentedEvent(data []byte) (event.Event, error) {
+	var e SessionPresentedEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse session presented event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeSessionPresented, ParseSessionPresentedEvent)
+}
@@ -0,0 +1,102 @@
+package caep
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+func TestSessionPresentedEvent_RoundTrip(t *testing.T) {
+	original := NewSessionPresentedEvent().
+		WithFingerprintUserAgent("abb0b6e7da81a42233f8f2b1a8ddb1b9a4c81611").
+		WithExternalSessionID("12505abe-b4a8-4bb5-b7b7-8a7f6b4ad7e6").
+		WithEventTimestamp(1615304991643).
+		WithReasonAdmin("en", "Session presented from a new network")
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	parsed, err := event.ParseEvent(EventTypeSessionPresented, data)
+	if err != nil {
+		t.Fatalf("ParseEvent() error = %v", err)
+	}
+
+	presented, ok := parsed.(*SessionPresentedEvent)
+	if !ok {
+		t.Fatalf("ParseEvent() returned %T, want *SessionPresentedEvent", parsed)
+	}
+
+	if fp, ok := presented.GetFingerprintUserAgent(); !ok || fp != "abb0b6e7da81a42233f8f2b1a8ddb1b9a4c81611" {
+		t.Errorf("GetFingerprintUserAgent() = %v, %v", fp, ok)
+	}
+
+	if extID, ok := presented.GetExternalSessionID(); !ok || extID != "12505abe-b4a8-4bb5-b7b7-8a7f6b4ad7e6" {
+		t.Errorf("GetExternalSessionID() = %v, %v", extID, ok)
+	}
+
+	if reason, ok := presented.GetReasonAdmin("en"); !ok || reason != "Session presented from a new network" {
+		t.Errorf("GetReasonAdmin() = %v, %v", reason, ok)
+	}
+
+	remarshaled, err := json.Marshal(presented)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if string(remarshaled) != string(data) {
+		t.Errorf("round trip = %s, want %s", remarshaled, data)
+	}
+}
+
+func TestSessionPresentedEvent_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		event   *SessionPresentedEvent
+		wantErr bool
+	}{
+		{
+			name:  "no members",
+			event: NewSessionPresentedEvent(),
+		},
+		{
+			name:  "all members",
+			event: NewSessionPresentedEvent().WithFingerprintUserAgent("fp").WithExternalSessionID("ext").WithEventTimestamp(1615304991643),
+		},
+		{
+			name:    "negative event timestamp",
+			event:   NewSessionPresentedEvent().WithEventTimestamp(-1),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.event.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseSessionPresentedEvent_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"fp_ua is not a string", `{"fp_ua": 42}`},
+		{"negative event timestamp", `{"event_timestamp": -1}`},
+		{"not an object", `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := event.ParseEvent(EventTypeSessionPresented, []byte(tt.data)); err == nil {
+				t.Error("ParseEvent() error = nil, want error")
+			}
+		})
+	}
+}
@@ -61,7 +61,7 @@ func (e *SessionRevokedEvent) Payload() interface{} {
 }
 
 func (e *SessionRevokedEvent) MarshalJSON() ([]byte, error) {
-	return json.Marshal(e.Payload())
+	return event.MarshalPayload(e)
 }
 
 func (e *SessionRevokedEvent) UnmarshalJSON(data []byte) error {
@@ -78,6 +78,10 @@ func (e *SessionRevokedEvent) UnmarshalJSON(data []byte) error {
 
 	e.Metadata = payload.EventMetadata
 
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
 	return e.Validate()
 }
 
@@ -92,5 +96,5 @@ func ParseSessionRevokedEvent(data []byte) (event.Event, error) {
 }
 
 func init() {
-	event.RegisterEventParser(EventTypeSessionRevoked, ParseSessionRevokedEvent)
+	register(EventTypeSessionRevoked, ParseSessionRevokedEvent)
 }
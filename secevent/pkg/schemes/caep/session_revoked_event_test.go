@@ -0,0 +1,59 @@
+package caep
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func TestSessionRevokedEvent_ExtensionsRoundTrip(t *testing.T) {
+	data := []byte(`{"event_timestamp":1700000000,"vendor_x":"y"}`)
+
+	var evt SessionRevokedEvent
+	if err := json.Unmarshal(data, &evt); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got := string(evt.GetExtensions()["vendor_x"]); got != `"y"` {
+		t.Errorf("vendor_x = %s, want \"y\"", got)
+	}
+
+	if _, ok := evt.GetExtensions()["event_timestamp"]; ok {
+		t.Error("modeled member event_timestamp reported as an extension")
+	}
+
+	marshaled, err := json.Marshal(&evt)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got, want map[string]interface{}
+	if err := json.Unmarshal(marshaled, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if err := json.Unmarshal(data, &want); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("round trip = %s, want %s", marshaled, data)
+	}
+
+	parsed, err := ParseSessionRevokedEvent(data)
+	if err != nil {
+		t.Fatalf("ParseSessionRevokedEvent() error = %v", err)
+	}
+
+	if got := string(parsed.(*SessionRevokedEvent).GetExtensions()["vendor_x"]); got != `"y"` {
+		t.Errorf("ParseSessionRevokedEvent() vendor_x = %s, want \"y\"", got)
+	}
+
+	if err := json.Unmarshal([]byte(`{"event_timestamp":1700000000}`), &evt); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if len(evt.GetExtensions()) != 0 {
+		t.Errorf("extensions of a previous decode kept: %v", evt.GetExtensions())
+	}
+}
@@ -65,7 +65,7 @@ func (e *TokenClaimsChangeEvent) Payload() interface{} {
 }
 
 func (e *TokenClaimsChangeEvent) MarshalJSON() ([]byte, error) {
-	return json.Marshal(e.Payload())
+	return event.MarshalPayload(e)
 }
 
 func (e *TokenClaimsChangeEvent) UnmarshalJSON(data []byte) error {
@@ -84,6 +84,10 @@ func (e *TokenClaimsChangeEvent) UnmarshalJSON(data []byte) error {
 	e.TokenClaimsChangePayload = payload.TokenClaimsChangePayload
 	e.Metadata = payload.EventMetadata
 
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
 	return e.Validate()
 }
 
@@ -126,5 +130,5 @@ func ParseTokenClaimsChangeEvent(data []byte) (event.Event, error) {
 }
 
 func init() {
-	event.RegisterEventParser(EventTypeTokenClaimsChange, ParseTokenClaimsChangeEvent)
+	register(EventTypeTokenClaimsChange, ParseTokenClaimsChangeEvent)
 }
@@ -0,0 +1,63 @@
+package risc
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type AccountCredentialChangeRequiredEvent struct {
+	BaseRISCEvent
+	// No additional fields
+}
+
+func NewAccountCredentialChangeRequiredEvent() *AccountCredentialChangeRequiredEvent {
+	e := &AccountCredentialChangeRequiredEvent{}
+
+	e.SetType(EventTypeAccountCredentialChangeRequired)
+
+	return e
+}
+
+func (e *AccountCredentialChangeRequiredEvent) Validate() error {
+	// The event carries no payload, so no validation needed
+	return nil
+}
+
+func (e *AccountCredentialChangeRequiredEvent) Payload() interface{} {
+	return struct{}{}
+}
+
+func (e *AccountCredentialChangeRequiredEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *AccountCredentialChangeRequiredEvent) UnmarshalJSON(data []byte) error {
+	var payload struct{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse account credential change required event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeAccountCredentialChangeRequired)
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseAccountCredentialChangeRequiredEvent(data []byte) (event.Event, error) {
+	var e AccountCredentialChangeRequiredEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse account credential change required event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeAccountCredentialChangeRequired, ParseAccountCredentialChangeRequiredEvent)
+}
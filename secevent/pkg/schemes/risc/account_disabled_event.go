@@ -0,0 +1,110 @@
+package risc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+// AccountDisabledReason represents why an account was disabled
+type AccountDisabledReason string
+
+const (
+	// AccountDisabledReasonHijacking indicates the account was disabled because it was hijacked
+	AccountDisabledReasonHijacking AccountDisabledReason = "hijacking"
+
+	// AccountDisabledReasonBulkAccount indicates the account was disabled because it is part of a bulk account operation
+	AccountDisabledReasonBulkAccount AccountDisabledReason = "bulk-account"
+)
+
+func IsValidAccountDisabledReason(reason AccountDisabledReason) bool {
+	switch reason {
+	case AccountDisabledReasonHijacking,
+		AccountDisabledReasonBulkAccount:
+		return true
+	default:
+		return false
+	}
+}
+
+type AccountDisabledPayload struct {
+	Reason *AccountDisabledReason `json:"reason,omitempty"` // OPTIONAL
+}
+
+type AccountDisabledEvent struct {
+	BaseRISCEvent
+	AccountDisabledPayload
+}
+
+func NewAccountDisabledEvent() *AccountDisabledEvent {
+	e := &AccountDisabledEvent{}
+
+	e.SetType(EventTypeAccountDisabled)
+
+	return e
+}
+
+func (e *AccountDisabledEvent) WithReason(reason AccountDisabledReason) *AccountDisabledEvent {
+	e.Reason = &reason
+
+	return e
+}
+
+func (e *AccountDisabledEvent) GetReason() (AccountDisabledReason, bool) {
+	if e.Reason == nil {
+		return "", false
+	}
+
+	return *e.Reason, true
+}
+
+func (e *AccountDisabledEvent) Validate() error {
+	if e.Reason != nil && !IsValidAccountDisabledReason(*e.Reason) {
+		return event.NewError(event.ErrCodeInvalidValue,
+			fmt.Sprintf("invalid account disabled reason: %s", *e.Reason),
+			"reason", "")
+	}
+
+	return nil
+}
+
+func (e *AccountDisabledEvent) Payload() interface{} {
+	return e.AccountDisabledPayload
+}
+
+func (e *AccountDisabledEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *AccountDisabledEvent) UnmarshalJSON(data []byte) error {
+	var payload AccountDisabledPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse account disabled event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeAccountDisabled)
+
+	e.AccountDisabledPayload = payload
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseAccountDisabledEvent(data []byte) (event.Event, error) {
+	var e AccountDisabledEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse account disabled event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeAccountDisabled, ParseAccountDisabledEvent)
+}
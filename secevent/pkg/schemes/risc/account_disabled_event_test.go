@@ -0,0 +1,71 @@
+package risc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+func TestAccountDisabledEvent_RoundTrip(t *testing.T) {
+	original := NewAccountDisabledEvent().WithReason(AccountDisabledReasonHijacking)
+
+	data, err := json.Marshal(original)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if string(data) != `{"reason":"hijacking"}` {
+		t.Errorf("unexpected payload: %s", data)
+	}
+
+	parsed, err := event.ParseEvent(EventTypeAccountDisabled, data)
+	if err != nil {
+		t.Fatalf("ParseEvent() error = %v", err)
+	}
+
+	disabled, ok := parsed.(*AccountDisabledEvent)
+	if !ok {
+		t.Fatalf("ParseEvent() returned %T, want *AccountDisabledEvent", parsed)
+	}
+
+	if reason, ok := disabled.GetReason(); !ok || reason != AccountDisabledReasonHijacking {
+		t.Errorf("GetReason() = %v, %v; want %v, true", reason, ok, AccountDisabledReasonHijacking)
+	}
+}
+
+func TestAccountDisabledEvent_InvalidReason(t *testing.T) {
+	_, err := event.ParseEvent(EventTypeAccountDisabled, []byte(`{"reason":"bored"}`))
+	if err == nil {
+		t.Fatal("expected error for unknown reason")
+	}
+}
+
+func TestRegisteredEventTypes(t *testing.T) {
+	types := []event.EventType{
+		EventTypeAccountCredentialChangeRequired,
+		EventTypeAccountPurged,
+		EventTypeAccountDisabled,
+		EventTypeAccountEnabled,
+		EventTypeIdentifierChanged,
+		EventTypeIdentifierRecycled,
+		EventTypeCredentialCompromise,
+		EventTypeOptIn,
+		EventTypeOptOutInitiated,
+		EventTypeOptOutCancelled,
+		EventTypeOptOutEffective,
+		EventTypeRecoveryActivated,
+		EventTypeRecoveryInformationChanged,
+		EventTypeSessionsRevoked,
+	}
+
+	for _, eventType := range types {
+		if !IsRiscEventType(eventType) {
+			t.Errorf("IsRiscEventType(%s) = false", eventType)
+		}
+
+		if !event.IsEventTypeRegistered(eventType) {
+			t.Errorf("no parser registered for %s", eventType)
+		}
+	}
+}
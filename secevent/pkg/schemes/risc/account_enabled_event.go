@@ -0,0 +1,63 @@
+package risc
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type AccountEnabledEvent struct {
+	BaseRISCEvent
+	// No additional fields
+}
+
+func NewAccountEnabledEvent() *AccountEnabledEvent {
+	e := &AccountEnabledEvent{}
+
+	e.SetType(EventTypeAccountEnabled)
+
+	return e
+}
+
+func (e *AccountEnabledEvent) Validate() error {
+	// The event carries no payload, so no validation needed
+	return nil
+}
+
+func (e *AccountEnabledEvent) Payload() interface{} {
+	return struct{}{}
+}
+
+func (e *AccountEnabledEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *AccountEnabledEvent) UnmarshalJSON(data []byte) error {
+	var payload struct{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse account enabled event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeAccountEnabled)
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseAccountEnabledEvent(data []byte) (event.Event, error) {
+	var e AccountEnabledEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse account enabled event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeAccountEnabled, ParseAccountEnabledEvent)
+}
@@ -0,0 +1,63 @@
+package risc
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type AccountPurgedEvent struct {
+	BaseRISCEvent
+	// No additional fields
+}
+
+func NewAccountPurgedEvent() *AccountPurgedEvent {
+	e := &AccountPurgedEvent{}
+
+	e.SetType(EventTypeAccountPurged)
+
+	return e
+}
+
+func (e *AccountPurgedEvent) Validate() error {
+	// The event carries no payload, so no validation needed
+	return nil
+}
+
+func (e *AccountPurgedEvent) Payload() interface{} {
+	return struct{}{}
+}
+
+func (e *AccountPurgedEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *AccountPurgedEvent) UnmarshalJSON(data []byte) error {
+	var payload struct{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse account purged event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeAccountPurged)
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseAccountPurgedEvent(data []byte) (event.Event, error) {
+	var e AccountPurgedEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse account purged event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeAccountPurged, ParseAccountPurgedEvent)
+}
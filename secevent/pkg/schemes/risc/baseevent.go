@@ -0,0 +1,15 @@
+package risc
+
+import (
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+// RISCEvent is the interface that all RISC events must implement
+type RISCEvent interface {
+	event.Event
+}
+
+// BaseRISCEvent provides common RISC event functionality
+type BaseRISCEvent struct {
+	event.BaseEvent
+}
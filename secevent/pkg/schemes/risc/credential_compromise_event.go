@@ -0,0 +1,153 @@
+package risc
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+)
+
+type CredentialCompromisePayload struct {
+	CredentialType caep.CredentialType `json:"credential_type"`           // REQUIRED
+	EventTimestamp *int64              `json:"event_timestamp,omitempty"` // OPTIONAL
+	ReasonAdmin    map[string]string   `json:"reason_admin,omitempty"`    // OPTIONAL
+	ReasonUser     map[string]string   `json:"reason_user,omitempty"`     // OPTIONAL
+}
+
+type CredentialCompromiseEvent struct {
+	BaseRISCEvent
+	CredentialCompromisePayload
+}
+
+func NewCredentialCompromiseEvent(credType caep.CredentialType) *CredentialCompromiseEvent {
+	e := &CredentialCompromiseEvent{
+		CredentialCompromisePayload: CredentialCompromisePayload{
+			CredentialType: credType,
+		},
+	}
+
+	e.SetType(EventTypeCredentialCompromise)
+
+	return e
+}
+
+func (e *CredentialCompromiseEvent) WithEventTimestamp(timestamp int64) *CredentialCompromiseEvent {
+	e.EventTimestamp = &timestamp
+
+	return e
+}
+
+func (e *CredentialCompromiseEvent) WithReasonAdmin(language, reason string) *CredentialCompromiseEvent {
+	if e.ReasonAdmin == nil {
+		e.ReasonAdmin = make(map[string]string)
+	}
+
+	e.ReasonAdmin[language] = reason
+
+	return e
+}
+
+func (e *CredentialCompromiseEvent) WithReasonUser(language, reason string) *CredentialCompromiseEvent {
+	if e.ReasonUser == nil {
+		e.ReasonUser = make(map[string]string)
+	}
+
+	e.ReasonUser[language] = reason
+
+	return e
+}
+
+func (e *CredentialCompromiseEvent) GetCredentialType() caep.CredentialType {
+	return e.CredentialType
+}
+
+func (e *CredentialCompromiseEvent) GetEventTimestamp() (int64, bool) {
+	if e.EventTimestamp == nil {
+		return 0, false
+	}
+
+	return *e.EventTimestamp, true
+}
+
+func (e *CredentialCompromiseEvent) GetReasonAdmin(language string) (string, bool) {
+	if e.ReasonAdmin == nil {
+		return "", false
+	}
+
+	reason, ok := e.ReasonAdmin[language]
+
+	return reason, ok
+}
+
+func (e *CredentialCompromiseEvent) GetReasonUser(language string) (string, bool) {
+	if e.ReasonUser == nil {
+		return "", false
+	}
+
+	reason, ok := e.ReasonUser[language]
+
+	return reason, ok
+}
+
+func (e *CredentialCompromiseEvent) Validate() error {
+	if e.CredentialType == "" {
+		return event.NewError(event.ErrCodeMissingValue,
+			"credential type is required",
+			"credential_type", "")
+	}
+
+	if !caep.IsValidCredentialType(e.CredentialType) {
+		return event.NewError(event.ErrCodeInvalidValue,
+			fmt.Sprintf("invalid credential type: %s", e.CredentialType),
+			"credential_type", "")
+	}
+
+	if e.EventTimestamp != nil && *e.EventTimestamp < 0 {
+		return event.NewError(event.ErrCodeInvalidValue,
+			"event timestamp cannot be negative",
+			"event_timestamp", "")
+	}
+
+	return nil
+}
+
+func (e *CredentialCompromiseEvent) Payload() interface{} {
+	return e.CredentialCompromisePayload
+}
+
+func (e *CredentialCompromiseEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *CredentialCompromiseEvent) UnmarshalJSON(data []byte) error {
+	var payload CredentialCompromisePayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse credential compromise event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeCredentialCompromise)
+
+	e.CredentialCompromisePayload = payload
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseCredentialCompromiseEvent(data []byte) (event.Event, error) {
+	var e CredentialCompromiseEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse credential compromise event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeCredentialCompromise, ParseCredentialCompromiseEvent)
+}
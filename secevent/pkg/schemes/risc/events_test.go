@@ -0,0 +1,163 @@
+package risc
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+)
+
+func TestEvents_ParseAndValidate(t *testing.T) {
+	tests := []struct {
+		eventType event.EventType
+		built     event.Event
+		valid     []string
+		invalid   []string
+	}{
+		{
+			eventType: EventTypeAccountCredentialChangeRequired,
+			built:     NewAccountCredentialChangeRequiredEvent(),
+			valid:     []string{`{}`},
+			invalid:   []string{`[]`},
+		},
+		{
+			eventType: EventTypeAccountPurged,
+			built:     NewAccountPurgedEvent(),
+			valid:     []string{`{}`},
+			invalid:   []string{`[]`},
+		},
+		{
+			eventType: EventTypeAccountDisabled,
+			built:     NewAccountDisabledEvent().WithReason(AccountDisabledReasonBulkAccount),
+			valid:     []string{`{}`, `{"reason":"hijacking"}`, `{"reason":"bulk-account"}`},
+			invalid:   []string{`{"reason":"bored"}`, `{"reason":""}`, `{"reason":1}`},
+		},
+		{
+			eventType: EventTypeAccountEnabled,
+			built:     NewAccountEnabledEvent(),
+			valid:     []string{`{}`},
+			invalid:   []string{`[]`},
+		},
+		{
+			eventType: EventTypeIdentifierChanged,
+			built:     NewIdentifierChangedEvent().WithNewValue("new@example.com"),
+			valid:     []string{`{}`, `{"new-value":"new@example.com"}`},
+			invalid:   []string{`{"new-value":"  "}`, `{"new-value":1}`},
+		},
+		{
+			eventType: EventTypeIdentifierRecycled,
+			built:     NewIdentifierRecycledEvent(),
+			valid:     []string{`{}`},
+			invalid:   []string{`[]`},
+		},
+		{
+			eventType: EventTypeCredentialCompromise,
+			built:     NewCredentialCompromiseEvent(caep.CredentialTypePassword).WithReasonAdmin("en", "Found in a breach corpus"),
+			valid:     []string{`{"credential_type":"password"}`, `{"credential_type":"x509","event_timestamp":1615304991643}`},
+			invalid: []string{
+				`{}`,
+				`{"credential_type":""}`,
+				`{"credential_type":"carrier-pigeon"}`,
+				`{"credential_type":"password","event_timestamp":-1}`,
+			},
+		},
+		{
+			eventType: EventTypeOptIn,
+			built:     NewOptInEvent(),
+			valid:     []string{`{}`},
+			invalid:   []string{`[]`},
+		},
+		{
+			eventType: EventTypeOptOutInitiated,
+			built:     NewOptOutInitiatedEvent(),
+			valid:     []string{`{}`},
+			invalid:   []string{`[]`},
+		},
+		{
+			eventType: EventTypeOptOutCancelled,
+			built:     NewOptOutCancelledEvent(),
+			valid:     []string{`{}`},
+			invalid:   []string{`[]`},
+		},
+		{
+			eventType: EventTypeOptOutEffective,
+			built:     NewOptOutEffectiveEvent(),
+			valid:     []string{`{}`},
+			invalid:   []string{`[]`},
+		},
+		{
+			eventType: EventTypeRecoveryActivated,
+			built:     NewRecoveryActivatedEvent(),
+			valid:     []string{`{}`},
+			invalid:   []string{`[]`},
+		},
+		{
+			eventType: EventTypeRecoveryInformationChanged,
+			built:     NewRecoveryInformationChangedEvent(),
+			valid:     []string{`{}`},
+			invalid:   []string{`[]`},
+		},
+		{
+			// Deprecated, but still parsed for older transmitters
+			eventType: EventTypeSessionsRevoked,
+			built:     NewSessionsRevokedEvent(),
+			valid:     []string{`{}`},
+			invalid:   []string{`[]`},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.eventType), func(t *testing.T) {
+			if tt.built.Type() != tt.eventType {
+				t.Errorf("constructor type = %s", tt.built.Type())
+			}
+
+			if err := tt.built.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+
+			data, err := json.Marshal(tt.built)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			for _, payload := range append([]string{string(data)}, tt.valid...) {
+				parsed, err := event.ParseEvent(tt.eventType, []byte(payload))
+				if err != nil {
+					t.Errorf("ParseEvent(%s) error = %v", payload, err)
+					continue
+				}
+
+				if reflect.TypeOf(parsed) != reflect.TypeOf(tt.built) {
+					t.Errorf("ParseEvent(%s) returned %T, want %T", payload, parsed, tt.built)
+				}
+
+				if parsed.Type() != tt.eventType {
+					t.Errorf("ParseEvent(%s).Type() = %s", payload, parsed.Type())
+				}
+			}
+
+			parsed, err := event.ParseEvent(tt.eventType, data)
+			if err == nil {
+				remarshaled, _ := json.Marshal(parsed)
+				if string(remarshaled) != string(data) {
+					t.Errorf("round trip = %s, want %s", remarshaled, data)
+				}
+			}
+
+			for _, payload := range tt.invalid {
+				if _, err := event.ParseEvent(tt.eventType, []byte(payload)); err == nil {
+					t.Errorf("ParseEvent(%s) error = nil, want error", payload)
+				}
+			}
+		})
+	}
+}
+
+func TestAccountDisabledEvent_ValidateReason(t *testing.T) {
+	if err := NewAccountDisabledEvent().WithReason("bored").Validate(); err == nil {
+		t.Error("Validate() accepted an unknown reason")
+	}
+}
@@ -0,0 +1,75 @@
+package risc
+
+import (
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+// RISC Event Types as defined in the OpenID RISC Event Types specification
+const (
+	// EventTypeAccountCredentialChangeRequired event type indicates that the account credentials must be changed
+	EventTypeAccountCredentialChangeRequired event.EventType = "https://schemas.openid.net/secevent/risc/event-type/account-credential-change-required"
+
+	// EventTypeAccountPurged event type indicates that the account has been permanently deleted
+	EventTypeAccountPurged event.EventType = "https://schemas.openid.net/secevent/risc/event-type/account-purged"
+
+	// EventTypeAccountDisabled event type indicates that the account has been disabled
+	EventTypeAccountDisabled event.EventType = "https://schemas.openid.net/secevent/risc/event-type/account-disabled"
+
+	// EventTypeAccountEnabled event type indicates that the account has been re-enabled
+	EventTypeAccountEnabled event.EventType = "https://schemas.openid.net/secevent/risc/event-type/account-enabled"
+
+	// EventTypeIdentifierChanged event type indicates that the identifier of the subject has changed
+	EventTypeIdentifierChanged event.EventType = "https://schemas.openid.net/secevent/risc/event-type/identifier-changed"
+
+	// EventTypeIdentifierRecycled event type indicates that the identifier is now used by a different principal
+	EventTypeIdentifierRecycled event.EventType = "https://schemas.openid.net/secevent/risc/event-type/identifier-recycled"
+
+	// EventTypeCredentialCompromise event type indicates that a credential has been compromised
+	EventTypeCredentialCompromise event.EventType = "https://schemas.openid.net/secevent/risc/event-type/credential-compromise"
+
+	// EventTypeOptIn event type indicates that the account owner opted in to RISC event exchange
+	EventTypeOptIn event.EventType = "https://schemas.openid.net/secevent/risc/event-type/opt-in"
+
+	// EventTypeOptOutInitiated event type indicates that the account owner initiated an opt-out
+	EventTypeOptOutInitiated event.EventType = "https://schemas.openid.net/secevent/risc/event-type/opt-out-initiated"
+
+	// EventTypeOptOutCancelled event type indicates that the account owner cancelled a pending opt-out
+	EventTypeOptOutCancelled event.EventType = "https://schemas.openid.net/secevent/risc/event-type/opt-out-cancelled"
+
+	// EventTypeOptOutEffective event type indicates that a pending opt-out has taken effect
+	EventTypeOptOutEffective event.EventType = "https://schemas.openid.net/secevent/risc/event-type/opt-out-effective"
+
+	// EventTypeRecoveryActivated event type indicates that the account recovery flow was activated
+	EventTypeRecoveryActivated event.EventType = "https://schemas.openid.net/secevent/risc/event-type/recovery-activated"
+
+	// EventTypeRecoveryInformationChanged event type indicates that the account recovery information has changed
+	EventTypeRecoveryInformationChanged event.EventType = "https://schemas.openid.net/secevent/risc/event-type/recovery-information-changed"
+
+	// EventTypeSessionsRevoked event type indicates that all sessions of the account have been revoked.
+	//
+	// Deprecated: superseded by the CAEP session-revoked event; kept for interoperability with older transmitters.
+	EventTypeSessionsRevoked event.EventType = "https://schemas.openid.net/secevent/risc/event-type/sessions-revoked"
+)
+
+// IsRiscEventType checks if the given event type is a valid RISC event type
+func IsRiscEventType(eventType event.EventType) bool {
+	switch eventType {
+	case EventTypeAccountCredentialChangeRequired,
+		EventTypeAccountPurged,
+		EventTypeAccountDisabled,
+		EventTypeAccountEnabled,
+		EventTypeIdentifierChanged,
+		EventTypeIdentifierRecycled,
+		EventTypeCredentialCompromise,
+		EventTypeOptIn,
+		EventTypeOptOutInitiated,
+		EventTypeOptOutCancelled,
+		EventTypeOptOutEffective,
+		EventTypeRecoveryActivated,
+		EventTypeRecoveryInformationChanged,
+		EventTypeSessionsRevoked:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,89 @@
+package risc
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type IdentifierChangedPayload struct {
+	NewValue *string `json:"new-value,omitempty"` // OPTIONAL
+}
+
+type IdentifierChangedEvent struct {
+	BaseRISCEvent
+	IdentifierChangedPayload
+}
+
+func NewIdentifierChangedEvent() *IdentifierChangedEvent {
+	e := &IdentifierChangedEvent{}
+
+	e.SetType(EventTypeIdentifierChanged)
+
+	return e
+}
+
+func (e *IdentifierChangedEvent) WithNewValue(newValue string) *IdentifierChangedEvent {
+	e.NewValue = &newValue
+
+	return e
+}
+
+func (e *IdentifierChangedEvent) GetNewValue() (string, bool) {
+	if e.NewValue == nil {
+		return "", false
+	}
+
+	return *e.NewValue, true
+}
+
+func (e *IdentifierChangedEvent) Validate() error {
+	if e.NewValue != nil && strings.TrimSpace(*e.NewValue) == "" {
+		return event.NewError(event.ErrCodeInvalidValue,
+			"new value cannot be empty when provided",
+			"new-value", "")
+	}
+
+	return nil
+}
+
+func (e *IdentifierChangedEvent) Payload() interface{} {
+	return e.IdentifierChangedPayload
+}
+
+func (e *IdentifierChangedEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *IdentifierChangedEvent) UnmarshalJSON(data []byte) error {
+	var payload IdentifierChangedPayload
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse identifier changed event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeIdentifierChanged)
+
+	e.IdentifierChangedPayload = payload
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseIdentifierChangedEvent(data []byte) (event.Event, error) {
+	var e IdentifierChangedEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse identifier changed event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeIdentifierChanged, ParseIdentifierChangedEvent)
+}
@@ -0,0 +1,63 @@
+package risc
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type IdentifierRecycledEvent struct {
+	BaseRISCEvent
+	// No additional fields
+}
+
+func NewIdentifierRecycledEvent() *IdentifierRecycledEvent {
+	e := &IdentifierRecycledEvent{}
+
+	e.SetType(EventTypeIdentifierRecycled)
+
+	return e
+}
+
+func (e *IdentifierRecycledEvent) Validate() error {
+	// The event carries no payload, so no validation needed
+	return nil
+}
+
+func (e *IdentifierRecycledEvent) Payload() interface{} {
+	return struct{}{}
+}
+
+func (e *IdentifierRecycledEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *IdentifierRecycledEvent) UnmarshalJSON(data []byte) error {
+	var payload struct{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse identifier recycled event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeIdentifierRecycled)
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseIdentifierRecycledEvent(data []byte) (event.Event, error) {
+	var e IdentifierRecycledEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse identifier recycled event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeIdentifierRecycled, ParseIdentifierRecycledEvent)
+}
@@ -0,0 +1,63 @@
+package risc
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type OptInEvent struct {
+	BaseRISCEvent
+	// No additional fields
+}
+
+func NewOptInEvent() *OptInEvent {
+	e := &OptInEvent{}
+
+	e.SetType(EventTypeOptIn)
+
+	return e
+}
+
+func (e *OptInEvent) Validate() error {
+	// The event carries no payload, so no validation needed
+	return nil
+}
+
+func (e *OptInEvent) Payload() interface{} {
+	return struct{}{}
+}
+
+func (e *OptInEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *OptInEvent) UnmarshalJSON(data []byte) error {
+	var payload struct{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse opt-in event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeOptIn)
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseOptInEvent(data []byte) (event.Event, error) {
+	var e OptInEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse opt-in event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeOptIn, ParseOptInEvent)
+}
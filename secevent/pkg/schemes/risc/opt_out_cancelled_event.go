@@ -0,0 +1,63 @@
+package risc
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type OptOutCancelledEvent struct {
+	BaseRISCEvent
+	// No additional fields
+}
+
+func NewOptOutCancelledEvent() *OptOutCancelledEvent {
+	e := &OptOutCancelledEvent{}
+
+	e.SetType(EventTypeOptOutCancelled)
+
+	return e
+}
+
+func (e *OptOutCancelledEvent) Validate() error {
+	// The event carries no payload, so no validation needed
+	return nil
+}
+
+func (e *OptOutCancelledEvent) Payload() interface{} {
+	return struct{}{}
+}
+
+func (e *OptOutCancelledEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *OptOutCancelledEvent) UnmarshalJSON(data []byte) error {
+	var payload struct{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse opt-out cancelled event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeOptOutCancelled)
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseOptOutCancelledEvent(data []byte) (event.Event, error) {
+	var e OptOutCancelledEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse opt-out cancelled event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeOptOutCancelled, ParseOptOutCancelledEvent)
+}
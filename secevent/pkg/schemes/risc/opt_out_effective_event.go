@@ -0,0 +1,63 @@
+package risc
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type OptOutEffectiveEvent struct {
+	BaseRISCEvent
+	// No additional fields
+}
+
+func NewOptOutEffectiveEvent() *OptOutEffectiveEvent {
+	e := &OptOutEffectiveEvent{}
+
+	e.SetType(EventTypeOptOutEffective)
+
+	return e
+}
+
+func (e *OptOutEffectiveEvent) Validate() error {
+	// The event carries no payload, so no validation needed
+	return nil
+}
+
+func (e *OptOutEffectiveEvent) Payload() interface{} {
+	return struct{}{}
+}
+
+func (e *OptOutEffectiveEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *OptOutEffectiveEvent) UnmarshalJSON(data []byte) error {
+	var payload struct{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse opt-out effective event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeOptOutEffective)
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseOptOutEffectiveEvent(data []byte) (event.Event, error) {
+	var e OptOutEffectiveEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse opt-out effective event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeOptOutEffective, ParseOptOutEffectiveEvent)
+}
@@ -0,0 +1,63 @@
+package risc
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type OptOutInitiatedEvent struct {
+	BaseRISCEvent
+	// No additional fields
+}
+
+func NewOptOutInitiatedEvent() *OptOutInitiatedEvent {
+	e := &OptOutInitiatedEvent{}
+
+	e.SetType(EventTypeOptOutInitiated)
+
+	return e
+}
+
+func (e *OptOutInitiatedEvent) Validate() error {
+	// The event carries no payload, so no validation needed
+	return nil
+}
+
+func (e *OptOutInitiatedEvent) Payload() interface{} {
+	return struct{}{}
+}
+
+func (e *OptOutInitiatedEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *OptOutInitiatedEvent) UnmarshalJSON(data []byte) error {
+	var payload struct{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse opt-out initiated event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeOptOutInitiated)
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseOptOutInitiatedEvent(data []byte) (event.Event, error) {
+	var e OptOutInitiatedEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse opt-out initiated event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeOptOutInitiated, ParseOptOutInitiatedEvent)
+}
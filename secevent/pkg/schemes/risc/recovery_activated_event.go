@@ -0,0 +1,63 @@
+package risc
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type RecoveryActivatedEvent struct {
+	BaseRISCEvent
+	// No additional fields
+}
+
+func NewRecoveryActivatedEvent() *RecoveryActivatedEvent {
+	e := &RecoveryActivatedEvent{}
+
+	e.SetType(EventTypeRecoveryActivated)
+
+	return e
+}
+
+func (e *RecoveryActivatedEvent) Validate() error {
+	// The event carries no payload, so no validation needed
+	return nil
+}
+
+func (e *RecoveryActivatedEvent) Payload() interface{} {
+	return struct{}{}
+}
+
+func (e *RecoveryActivatedEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *RecoveryActivatedEvent) UnmarshalJSON(data []byte) error {
+	var payload struct{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse recovery activated event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeRecoveryActivated)
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseRecoveryActivatedEvent(data []byte) (event.Event, error) {
+	var e RecoveryActivatedEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse recovery activated event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeRecoveryActivated, ParseRecoveryActivatedEvent)
+}
@@ -0,0 +1,63 @@
+package risc
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+type RecoveryInformationChangedEvent struct {
+	BaseRISCEvent
+	// No additional fields
+}
+
+func NewRecoveryInformationChangedEvent() *RecoveryInformationChangedEvent {
+	e := &RecoveryInformationChangedEvent{}
+
+	e.SetType(EventTypeRecoveryInformationChanged)
+
+	return e
+}
+
+func (e *RecoveryInformationChangedEvent) Validate() error {
+	// The event carries no payload, so no validation needed
+	return nil
+}
+
+func (e *RecoveryInformationChangedEvent) Payload() interface{} {
+	return struct{}{}
+}
+
+func (e *RecoveryInformationChangedEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *RecoveryInformationChangedEvent) UnmarshalJSON(data []byte) error {
+	var payload struct{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse recovery information changed event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeRecoveryInformationChanged)
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseRecoveryInformationChangedEvent(data []byte) (event.Event, error) {
+	var e RecoveryInformationChangedEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse recovery information changed event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeRecoveryInformationChanged, ParseRecoveryInformationChangedEvent)
+}
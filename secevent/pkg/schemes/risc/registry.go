@@ -0,0 +1,21 @@
+package risc
+
+import (
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+// parsers holds the parser of every RISC event, filled by each event's init
+var parsers = map[event.EventType]func([]byte) (event.Event, error){}
+
+// register records a RISC event parser and registers it with event.DefaultRegistry
+func register(eventType event.EventType, parser func([]byte) (event.Event, error)) {
+	parsers[eventType] = parser
+	event.RegisterEventParser(eventType, parser)
+}
+
+// RegisterEvents registers the parsers of all RISC events with the given registry
+func RegisterEvents(registry *event.Registry) {
+	for eventType, parser := range parsers {
+		registry.Register(eventType, parser)
+	}
+}
@@ -0,0 +1,66 @@
+package risc
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+// SessionsRevokedEvent is the legacy RISC sessions-revoked event.
+//
+// Deprecated: use caep.SessionRevokedEvent for new transmitters.
+type SessionsRevokedEvent struct {
+	BaseRISCEvent
+	// No additional fields
+}
+
+func NewSessionsRevokedEvent() *SessionsRevokedEvent {
+	e := &SessionsRevokedEvent{}
+
+	e.SetType(EventTypeSessionsRevoked)
+
+	return e
+}
+
+func (e *SessionsRevokedEvent) Validate() error {
+	// The event carries no payload, so no validation needed
+	return nil
+}
+
+func (e *SessionsRevokedEvent) Payload() interface{} {
+	return struct{}{}
+}
+
+func (e *SessionsRevokedEvent) MarshalJSON() ([]byte, error) {
+	return event.MarshalPayload(e)
+}
+
+func (e *SessionsRevokedEvent) UnmarshalJSON(data []byte) error {
+	var payload struct{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return event.NewError(event.ErrCodeParseError,
+			"failed to parse sessions revoked event data", "", err.Error())
+	}
+
+	e.SetType(EventTypeSessionsRevoked)
+
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
+	return e.Validate()
+}
+
+func ParseSessionsRevokedEvent(data []byte) (event.Event, error) {
+	var e SessionsRevokedEvent
+	if err := json.Unmarshal(data, &e); err != nil {
+		return nil, event.NewError(event.ErrCodeParseError,
+			"failed to parse sessions revoked event", "", err.Error())
+	}
+
+	return &e, nil
+}
+
+func init() {
+	register(EventTypeSessionsRevoked, ParseSessionsRevokedEvent)
+}
@@ -87,7 +87,7 @@ func (e *StreamUpdateEvent) Payload() interface{} {
 }
 
 func (e *StreamUpdateEvent) MarshalJSON() ([]byte, error) {
-	return json.Marshal(e.Payload())
+	return event.MarshalPayload(e)
 }
 
 func (e *StreamUpdateEvent) UnmarshalJSON(data []byte) error {
@@ -101,6 +101,10 @@ func (e *StreamUpdateEvent) UnmarshalJSON(data []byte) error {
 
 	e.StreamUpdatePayload = payload
 
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
 	return e.Validate()
 }
 
@@ -115,5 +119,5 @@ func ParseStreamUpdateEvent(data []byte) (event.Event, error) {
 }
 
 func init() {
-	event.RegisterEventParser(EventTypeStreamUpdate, ParseStreamUpdateEvent)
+	register(EventTypeStreamUpdate, ParseStreamUpdateEvent)
 }
@@ -47,7 +47,7 @@ func (e *VerificationEvent) Payload() interface{} {
 }
 
 func (e *VerificationEvent) MarshalJSON() ([]byte, error) {
-	return json.Marshal(e.Payload())
+	return event.MarshalPayload(e)
 }
 
 func (e *VerificationEvent) UnmarshalJSON(data []byte) error {
@@ -61,6 +61,10 @@ func (e *VerificationEvent) UnmarshalJSON(data []byte) error {
 
 	e.VerificationPayload = payload
 
+	if err := event.ExtractExtensions(e, data); err != nil {
+		return err
+	}
+
 	return e.Validate()
 }
 
@@ -75,5 +79,5 @@ func ParseVerificationEvent(data []byte) (event.Event, error) {
 }
 
 func init() {
-	event.RegisterEventParser(EventTypeVerification, ParseVerificationEvent)
+	register(EventTypeVerification, ParseVerificationEvent)
 }
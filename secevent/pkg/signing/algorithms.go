@@ -0,0 +1,85 @@
+package signing
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// SigningMethodForKey returns the default JWS signing method for a private or public key:
+// RS256 for RSA keys, ES256/ES384/ES512 for ECDSA keys on P-256/P-384/P-521, and EdDSA
+// for Ed25519 keys. Use WithSigningMethod to select RSA-PSS (PS256/PS384/PS512) or a
+// stronger RSA hash.
+func SigningMethodForKey(key interface{}) (jwt.SigningMethod, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return jwt.SigningMethodRS256, nil
+	case *rsa.PublicKey:
+		return jwt.SigningMethodRS256, nil
+	case *ecdsa.PrivateKey:
+		return signingMethodForCurve(k.Curve)
+	case *ecdsa.PublicKey:
+		return signingMethodForCurve(k.Curve)
+	case ed25519.PrivateKey, *ed25519.PrivateKey, ed25519.PublicKey, *ed25519.PublicKey:
+		return jwt.SigningMethodEdDSA, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type: %T", k)
+	}
+}
+
+func signingMethodForCurve(curve elliptic.Curve) (jwt.SigningMethod, error) {
+	switch curve {
+	case elliptic.P256():
+		return jwt.SigningMethodES256, nil
+	case elliptic.P384():
+		return jwt.SigningMethodES384, nil
+	case elliptic.P521():
+		return jwt.SigningMethodES512, nil
+	default:
+		return nil, fmt.Errorf("unsupported elliptic curve: %s", curve.Params().Name)
+	}
+}
+
+// checkSigningMethod reports whether the signing method can sign with the given private key
+func checkSigningMethod(method jwt.SigningMethod, key crypto.PrivateKey) error {
+	if method == nil {
+		return fmt.Errorf("signing method is required")
+	}
+
+	var ok bool
+
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		switch method.(type) {
+		case *jwt.SigningMethodRSA, *jwt.SigningMethodRSAPSS:
+			ok = true
+		}
+	case *ecdsa.PrivateKey:
+		if m, isECDSA := method.(*jwt.SigningMethodECDSA); isECDSA {
+			ok = m.CurveBits == k.Curve.Params().BitSize
+		}
+	case ed25519.PrivateKey:
+		_, ok = method.(*jwt.SigningMethodEd25519)
+	}
+
+	if !ok {
+		return fmt.Errorf("signing method %s cannot be used with key type %T", method.Alg(), key)
+	}
+
+	return nil
+}
+
+// normalizeSigningKey converts key representations that golang-jwt cannot sign with
+// into ones it can
+func normalizeSigningKey(key crypto.PrivateKey) crypto.PrivateKey {
+	if k, ok := key.(*ed25519.PrivateKey); ok && k != nil {
+		return *k
+	}
+
+	return key
+}
@@ -0,0 +1,229 @@
+package signing
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/asn1"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"math/big"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RemoteSigner is implemented by signing backends that keep the private key outside the
+// process, such as a cloud KMS or an HSM. Adapters only need to sign a precomputed digest;
+// CryptoSigner takes care of building the JWS signing input and encoding the signature.
+type RemoteSigner interface {
+	// Public returns the public key corresponding to the remote private key
+	Public() crypto.PublicKey
+
+	// SignDigest signs digest with the remote key. opts carries the hash function and,
+	// for RSA-PSS, *rsa.PSSOptions. ECDSA signatures are expected in ASN.1 DER form as
+	// returned by crypto.Signer. For Ed25519, digest is the unhashed message and opts
+	// is crypto.Hash(0).
+	SignDigest(ctx context.Context, digest []byte, opts crypto.SignerOpts) ([]byte, error)
+}
+
+// cryptoSignerAdapter exposes a crypto.Signer as a RemoteSigner
+type cryptoSignerAdapter struct {
+	signer crypto.Signer
+	rand   io.Reader
+}
+
+func (a *cryptoSignerAdapter) Public() crypto.PublicKey {
+	return a.signer.Public()
+}
+
+func (a *cryptoSignerAdapter) SignDigest(_ context.Context, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	return a.signer.Sign(a.rand, digest, opts)
+}
+
+// CryptoSigner signs SecEvents with a crypto.Signer or RemoteSigner, so the private key
+// never has to be held in process memory
+type CryptoSigner struct {
+	signer        RemoteSigner
+	signingMethod jwt.SigningMethod
+	keyID         *string
+}
+
+// NewCryptoSigner creates a signer backed by a crypto.Signer. The signing method is
+// derived from the signer's public key (see SigningMethodForKey).
+func NewCryptoSigner(signer crypto.Signer) (*CryptoSigner, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("signer is required")
+	}
+
+	return NewRemoteSigner(&cryptoSignerAdapter{signer: signer, rand: rand.Reader})
+}
+
+// NewRemoteSigner creates a signer backed by a RemoteSigner. The signing method is
+// derived from the remote signer's public key (see SigningMethodForKey).
+func NewRemoteSigner(signer RemoteSigner) (*CryptoSigner, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("signer is required")
+	}
+
+	signingMethod, err := SigningMethodForKey(signer.Public())
+	if err != nil {
+		return nil, err
+	}
+
+	return &CryptoSigner{
+		signer:        signer,
+		signingMethod: signingMethod,
+	}, nil
+}
+
+// WithKeyID sets the kid header of signed SecEvents
+func (s *CryptoSigner) WithKeyID(kid string) *CryptoSigner {
+	s.keyID = &kid
+
+	return s
+}
+
+// WithSigningMethod overrides the signing method derived from the public key,
+// e.g. to use jwt.SigningMethodPS256 with an RSA key
+func (s *CryptoSigner) WithSigningMethod(method jwt.SigningMethod) *CryptoSigner {
+	s.signingMethod = method
+
+	return s
+}
+
+func (s *CryptoSigner) Sign(claims jwt.Claims) (string, error) {
+	return s.SignContext(context.Background(), claims)
+}
+
+// SignContext signs the claims, passing ctx to the remote signer
+func (s *CryptoSigner) SignContext(ctx context.Context, claims jwt.Claims) (string, error) {
+	token := jwt.NewWithClaims(s.signingMethod, claims)
+
+	if s.keyID != nil {
+		token.Header["kid"] = *s.keyID
+	}
+
+	token.Header["typ"] = "secevent+jwt"
+
+	signingString, err := token.SigningString()
+	if err != nil {
+		return "", fmt.Errorf("failed to build signing input: %w", err)
+	}
+
+	signature, err := s.signInput(ctx, []byte(signingString))
+	if err != nil {
+		return "", err
+	}
+
+	return signingString + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func (s *CryptoSigner) SigningMethod() jwt.SigningMethod {
+	return s.signingMethod
+}
+
+func (s *CryptoSigner) KeyID() *string {
+	return s.keyID
+}
+
+func (s *CryptoSigner) Public() crypto.PublicKey {
+	return s.signer.Public()
+}
+
+// signInput signs the JWS signing input and returns the signature in JWS encoding
+func (s *CryptoSigner) signInput(ctx context.Context, input []byte) ([]byte, error) {
+	switch method := s.signingMethod.(type) {
+	case *jwt.SigningMethodECDSA:
+		digest, err := hashInput(method.Hash, input)
+		if err != nil {
+			return nil, err
+		}
+
+		signature, err := s.signer.SignDigest(ctx, digest, method.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign token: %w", err)
+		}
+
+		return ecdsaSignatureToJWS(signature, method.KeySize)
+	case *jwt.SigningMethodRSAPSS:
+		digest, err := hashInput(method.Hash, input)
+		if err != nil {
+			return nil, err
+		}
+
+		signature, err := s.signer.SignDigest(ctx, digest, &rsa.PSSOptions{
+			SaltLength: rsa.PSSSaltLengthEqualsHash,
+			Hash:       method.Hash,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign token: %w", err)
+		}
+
+		return signature, nil
+	case *jwt.SigningMethodRSA:
+		digest, err := hashInput(method.Hash, input)
+		if err != nil {
+			return nil, err
+		}
+
+		signature, err := s.signer.SignDigest(ctx, digest, method.Hash)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign token: %w", err)
+		}
+
+		return signature, nil
+	case *jwt.SigningMethodEd25519:
+		signature, err := s.signer.SignDigest(ctx, input, crypto.Hash(0))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign token: %w", err)
+		}
+
+		return signature, nil
+	default:
+		return nil, fmt.Errorf("unsupported signing method: %s", s.signingMethod.Alg())
+	}
+}
+
+func hashInput(hash crypto.Hash, input []byte) ([]byte, error) {
+	if !hash.Available() {
+		return nil, fmt.Errorf("hash function %s is not available", hash)
+	}
+
+	h := hash.New()
+	h.Write(input)
+
+	return h.Sum(nil), nil
+}
+
+// ecdsaSignatureToJWS converts an ASN.1 DER ECDSA signature into the fixed-size R||S
+// form required by JWS. Signatures that are already in R||S form are passed through.
+func ecdsaSignatureToJWS(signature []byte, keySize int) ([]byte, error) {
+	var parsed struct {
+		R, S *big.Int
+	}
+
+	rest, err := asn1.Unmarshal(signature, &parsed)
+	if err != nil || len(rest) > 0 {
+		if len(signature) == 2*keySize {
+			return signature, nil
+		}
+
+		return nil, fmt.Errorf("invalid ECDSA signature encoding")
+	}
+
+	if parsed.R == nil || parsed.S == nil || parsed.R.Sign() <= 0 || parsed.S.Sign() <= 0 {
+		return nil, fmt.Errorf("invalid ECDSA signature values")
+	}
+
+	if (parsed.R.BitLen()+7)/8 > keySize || (parsed.S.BitLen()+7)/8 > keySize {
+		return nil, fmt.Errorf("ECDSA signature does not match key size")
+	}
+
+	out := make([]byte, 2*keySize)
+	parsed.R.FillBytes(out[:keySize])
+	parsed.S.FillBytes(out[keySize:])
+
+	return out, nil
+}
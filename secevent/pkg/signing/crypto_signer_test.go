@@ -0,0 +1,139 @@
+package signing_test
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"sync/atomic"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/parser"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/signing"
+)
+
+// fakeKMS is an in-memory RemoteSigner that records how it was called
+type fakeKMS struct {
+	key   crypto.Signer
+	calls atomic.Int32
+}
+
+func (k *fakeKMS) Public() crypto.PublicKey {
+	return k.key.Public()
+}
+
+func (k *fakeKMS) SignDigest(ctx context.Context, digest []byte, opts crypto.SignerOpts) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	k.calls.Add(1)
+
+	return k.key.Sign(rand.Reader, digest, opts)
+}
+
+func TestCryptoSigner(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+
+	newECKey := func(curve elliptic.Curve) *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatalf("failed to generate EC key: %v", err)
+		}
+
+		return key
+	}
+
+	tests := []struct {
+		name    string
+		key     crypto.Signer
+		method  jwt.SigningMethod
+		wantAlg string
+	}{
+		{name: "ES256", key: newECKey(elliptic.P256()), wantAlg: "ES256"},
+		{name: "ES384", key: newECKey(elliptic.P384()), wantAlg: "ES384"},
+		{name: "ES512", key: newECKey(elliptic.P521()), wantAlg: "ES512"},
+		{name: "RS256", key: rsaKey, wantAlg: "RS256"},
+		{name: "RS512", key: rsaKey, method: jwt.SigningMethodRS512, wantAlg: "RS512"},
+		{name: "PS256", key: rsaKey, method: jwt.SigningMethodPS256, wantAlg: "PS256"},
+		{name: "EdDSA", key: edKey, wantAlg: "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			signer, err := signing.NewCryptoSigner(tt.key)
+			if err != nil {
+				t.Fatalf("NewCryptoSigner() error = %v", err)
+			}
+
+			signer.WithKeyID("hsm-key-1")
+
+			if tt.method != nil {
+				signer.WithSigningMethod(tt.method)
+			}
+
+			signed, err := signer.Sign(newTestSecEvent(t))
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			if alg := headerAlg(t, signed); alg != tt.wantAlg {
+				t.Errorf("alg = %s, want %s", alg, tt.wantAlg)
+			}
+
+			p := parser.NewParser(parser.WithPublicKey(tt.key.Public(), "hsm-key-1"))
+			if _, err := p.ParseSecEvent(signed); err != nil {
+				t.Errorf("ParseSecEvent() error = %v", err)
+			}
+		})
+	}
+}
+
+func TestRemoteSigner(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	kms := &fakeKMS{key: key}
+
+	signer, err := signing.NewRemoteSigner(kms)
+	if err != nil {
+		t.Fatalf("NewRemoteSigner() error = %v", err)
+	}
+
+	signer.WithKeyID("kms-key-1")
+
+	signed, err := signer.Sign(newTestSecEvent(t))
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	if kms.calls.Load() != 1 {
+		t.Errorf("expected one remote signing call, got %d", kms.calls.Load())
+	}
+
+	p := parser.NewParser(parser.WithPublicKey(&key.PublicKey, "kms-key-1"))
+	if _, err := p.ParseSecEvent(signed); err != nil {
+		t.Errorf("ParseSecEvent() error = %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := signer.SignContext(ctx, newTestSecEvent(t)); err == nil {
+		t.Error("expected SignContext to propagate context cancellation")
+	}
+}
@@ -0,0 +1,125 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"fmt"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwa"
+	"github.com/lestrrat-go/jwx/v2/jwe"
+)
+
+// encryptionConfig holds the recipient key used to wrap a signed SecEvent in a JWE
+type encryptionConfig struct {
+	key       interface{}
+	algorithm jwa.KeyEncryptionAlgorithm
+	keyID     *string
+}
+
+func (c *encryptionConfig) validate() error {
+	switch c.algorithm {
+	case jwa.RSA_OAEP, jwa.RSA_OAEP_256:
+		if _, ok := c.key.(*rsa.PublicKey); !ok {
+			return fmt.Errorf("key encryption algorithm %s requires an *rsa.PublicKey, got %T", c.algorithm, c.key)
+		}
+	case jwa.ECDH_ES, jwa.ECDH_ES_A128KW, jwa.ECDH_ES_A192KW, jwa.ECDH_ES_A256KW:
+		if _, ok := c.key.(*ecdsa.PublicKey); !ok {
+			return fmt.Errorf("key encryption algorithm %s requires an *ecdsa.PublicKey, got %T", c.algorithm, c.key)
+		}
+	default:
+		return fmt.Errorf("unsupported key encryption algorithm: %s", c.algorithm)
+	}
+
+	return nil
+}
+
+// encrypt wraps the compact JWS in a compact JWE using A256GCM content encryption
+func (c *encryptionConfig) encrypt(signedToken string) (string, error) {
+	headers := jwe.NewHeaders()
+	if err := headers.Set(jwe.ContentTypeKey, "JWT"); err != nil {
+		return "", fmt.Errorf("failed to set content type header: %w", err)
+	}
+
+	if c.keyID != nil {
+		if err := headers.Set(jwe.KeyIDKey, *c.keyID); err != nil {
+			return "", fmt.Errorf("failed to set key ID header: %w", err)
+		}
+	}
+
+	encrypted, err := jwe.Encrypt([]byte(signedToken),
+		jwe.WithKey(c.algorithm, c.key),
+		jwe.WithContentEncryption(jwa.A256GCM),
+		jwe.WithProtectedHeaders(headers),
+	)
+	if err != nil {
+		return "", fmt.Errorf("failed to encrypt token: %w", err)
+	}
+
+	return string(encrypted), nil
+}
+
+// WithEncryption enables encrypt-after-sign: the signed SecEvent is wrapped in a
+// JWE addressed to the given recipient public key. Supported algorithms are
+// RSA-OAEP and RSA-OAEP-256 for RSA keys, and ECDH-ES (optionally with AES key
+// wrap) for ECDSA keys. Content is always encrypted with A256GCM.
+func WithEncryption(recipientKey interface{}, algorithm jwa.KeyEncryptionAlgorithm) SignerOption {
+	return func(s *DefaultSigner) {
+		s.encryption = &encryptionConfig{
+			key:       recipientKey,
+			algorithm: algorithm,
+		}
+	}
+}
+
+// WithEncryptionKeyID sets the kid header of the JWE so receivers can select their decryption key.
+// It must be used together with WithEncryption, in any order; NewSigner returns an error otherwise.
+func WithEncryptionKeyID(kid string) SignerOption {
+	return func(s *DefaultSigner) {
+		s.encryptionKID = &kid
+	}
+}
+
+// EncryptingSigner wraps any Signer and encrypts the tokens it produces
+type EncryptingSigner struct {
+	signer     Signer
+	encryption *encryptionConfig
+}
+
+// NewEncryptingSigner returns a Signer that encrypts the output of the given signer
+// for the recipient key using the given key encryption algorithm.
+func NewEncryptingSigner(signer Signer, recipientKey interface{}, algorithm jwa.KeyEncryptionAlgorithm) (*EncryptingSigner, error) {
+	if signer == nil {
+		return nil, fmt.Errorf("signer is required")
+	}
+
+	encryption := &encryptionConfig{
+		key:       recipientKey,
+		algorithm: algorithm,
+	}
+
+	if err := encryption.validate(); err != nil {
+		return nil, err
+	}
+
+	return &EncryptingSigner{
+		signer:     signer,
+		encryption: encryption,
+	}, nil
+}
+
+// WithKeyID sets the kid header of the JWE
+func (s *EncryptingSigner) WithKeyID(kid string) *EncryptingSigner {
+	s.encryption.keyID = &kid
+
+	return s
+}
+
+func (s *EncryptingSigner) Sign(claims jwt.Claims) (string, error) {
+	signedToken, err := s.signer.Sign(claims)
+	if err != nil {
+		return "", err
+	}
+
+	return s.encryption.encrypt(signedToken)
+}
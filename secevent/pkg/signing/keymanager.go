@@ -0,0 +1,362 @@
+package signing
+
+import (
+	"crypto"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+// KeyState represents the lifecycle state of a managed signing key
+type KeyState uint8
+
+const (
+	// KeyStatePending keys are published in the JWKS but not yet used for signing
+	KeyStatePending KeyState = iota
+	// KeyStateActive is the single key used for signing
+	KeyStateActive
+	// KeyStateRetiring keys are no longer used for signing but stay published until their grace period ends
+	KeyStateRetiring
+)
+
+// String returns the string representation of the KeyState
+func (s KeyState) String() string {
+	switch s {
+	case KeyStatePending:
+		return "pending"
+	case KeyStateActive:
+		return "active"
+	case KeyStateRetiring:
+		return "retiring"
+	default:
+		return "unknown"
+	}
+}
+
+const (
+	// DefaultRetirementGracePeriod is how long retiring keys stay in the published JWKS
+	DefaultRetirementGracePeriod = 24 * time.Hour
+
+	// DefaultJWKSMaxAge is the Cache-Control max-age of the published JWKS
+	DefaultJWKSMaxAge = 5 * time.Minute
+)
+
+type managedKey struct {
+	kid       string
+	signer    *CryptoSigner
+	state     KeyState
+	retiredAt time.Time
+}
+
+// KeyManager holds the signing keys of a transmitter, signs with the active key and
+// publishes the public keys as a JWKS. Keys move from pending to active to retiring;
+// retiring keys remain published for the grace period so receivers holding a cached
+// JWKS can still verify SecEvents signed before the rotation. It is safe for concurrent use.
+type KeyManager struct {
+	gracePeriod time.Duration
+	jwksMaxAge  time.Duration
+	now         func() time.Time
+
+	mu     sync.RWMutex
+	keys   map[string]*managedKey
+	order  []string
+	active string
+}
+
+// KeyManagerOption defines the function signature for key manager options
+type KeyManagerOption func(*KeyManager)
+
+// WithRetirementGracePeriod sets how long retiring keys stay published
+func WithRetirementGracePeriod(period time.Duration) KeyManagerOption {
+	return func(m *KeyManager) {
+		m.gracePeriod = period
+	}
+}
+
+// WithJWKSMaxAge sets the Cache-Control max-age of the JWKS served by JWKSHandler
+func WithJWKSMaxAge(maxAge time.Duration) KeyManagerOption {
+	return func(m *KeyManager) {
+		m.jwksMaxAge = maxAge
+	}
+}
+
+// NewKeyManager creates an empty key manager with the provided options
+func NewKeyManager(opts ...KeyManagerOption) *KeyManager {
+	m := &KeyManager{
+		gracePeriod: DefaultRetirementGracePeriod,
+		jwksMaxAge:  DefaultJWKSMaxAge,
+		now:         time.Now,
+		keys:        make(map[string]*managedKey),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// AddKey adds an in-process or crypto.Signer backed key in the pending state
+func (m *KeyManager) AddKey(kid string, key crypto.Signer) error {
+	if key == nil {
+		return fmt.Errorf("key is required")
+	}
+
+	signer, err := NewCryptoSigner(key)
+	if err != nil {
+		return err
+	}
+
+	return m.add(kid, signer)
+}
+
+// AddRemoteKey adds a RemoteSigner backed key in the pending state
+func (m *KeyManager) AddRemoteKey(kid string, key RemoteSigner) error {
+	signer, err := NewRemoteSigner(key)
+	if err != nil {
+		return err
+	}
+
+	return m.add(kid, signer)
+}
+
+func (m *KeyManager) add(kid string, signer *CryptoSigner) error {
+	if kid == "" {
+		return fmt.Errorf("key ID is required")
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if _, exists := m.keys[kid]; exists {
+		return fmt.Errorf("key %s already exists", kid)
+	}
+
+	signer.WithKeyID(kid)
+
+	m.keys[kid] = &managedKey{
+		kid:    kid,
+		signer: signer,
+		state:  KeyStatePending,
+	}
+	m.order = append(m.order, kid)
+
+	return nil
+}
+
+// Activate makes the key the active signing key. The previously active key, if any, starts retiring.
+func (m *KeyManager) Activate(kid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.keys[kid]
+	if !ok {
+		return fmt.Errorf("key %s not found", kid)
+	}
+
+	if key.state == KeyStateRetiring {
+		return fmt.Errorf("key %s is retiring and cannot be activated", kid)
+	}
+
+	if m.active != "" && m.active != kid {
+		m.retire(m.keys[m.active])
+	}
+
+	key.state = KeyStateActive
+	m.active = kid
+
+	return nil
+}
+
+// Retire stops signing with the key and starts its grace period
+func (m *KeyManager) Retire(kid string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	key, ok := m.keys[kid]
+	if !ok {
+		return fmt.Errorf("key %s not found", kid)
+	}
+
+	if key.state != KeyStateRetiring {
+		m.retire(key)
+	}
+
+	return nil
+}
+
+// retire must be called with mu held
+func (m *KeyManager) retire(key *managedKey) {
+	key.state = KeyStateRetiring
+	key.retiredAt = m.now()
+
+	if m.active == key.kid {
+		m.active = ""
+	}
+}
+
+// Remove deletes a key immediately, regardless of its state
+func (m *KeyManager) Remove(kid string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.remove(kid)
+}
+
+// remove must be called with mu held
+func (m *KeyManager) remove(kid string) {
+	if _, ok := m.keys[kid]; !ok {
+		return
+	}
+
+	delete(m.keys, kid)
+
+	if m.active == kid {
+		m.active = ""
+	}
+
+	for i, id := range m.order {
+		if id == kid {
+			m.order = append(m.order[:i], m.order[i+1:]...)
+
+			break
+		}
+	}
+}
+
+// Prune removes retiring keys whose grace period has ended
+func (m *KeyManager) Prune() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+
+	for _, kid := range append([]string(nil), m.order...) {
+		if key := m.keys[kid]; key.state == KeyStateRetiring && !m.published(key, now) {
+			m.remove(kid)
+		}
+	}
+}
+
+// KeyState returns the state of the key
+func (m *KeyManager) KeyState(kid string) (KeyState, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	key, ok := m.keys[kid]
+	if !ok {
+		return 0, false
+	}
+
+	return key.state, true
+}
+
+// ActiveKeyID returns the key ID of the active signing key
+func (m *KeyManager) ActiveKeyID() (string, bool) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	return m.active, m.active != ""
+}
+
+// Sign signs the claims with the active key and stamps its kid
+func (m *KeyManager) Sign(claims jwt.Claims) (string, error) {
+	m.mu.RLock()
+	key, ok := m.keys[m.active]
+	m.mu.RUnlock()
+
+	if !ok {
+		return "", fmt.Errorf("no active signing key")
+	}
+
+	return key.signer.Sign(claims)
+}
+
+// published reports whether the key belongs in the public JWKS. Must be called with mu held.
+func (m *KeyManager) published(key *managedKey, now time.Time) bool {
+	if key.state != KeyStateRetiring {
+		return true
+	}
+
+	return now.Before(key.retiredAt.Add(m.gracePeriod))
+}
+
+// PublicJWKS returns the public keys of all pending, active and retiring keys
+// whose grace period has not ended
+func (m *KeyManager) PublicJWKS() (jwk.Set, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	now := m.now()
+	keySet := jwk.NewSet()
+
+	for _, kid := range m.order {
+		managed := m.keys[kid]
+		if !m.published(managed, now) {
+			continue
+		}
+
+		key, err := jwk.FromRaw(managed.signer.Public())
+		if err != nil {
+			return nil, fmt.Errorf("failed to convert key %s: %w", kid, err)
+		}
+
+		if err := key.Set(jwk.KeyIDKey, kid); err != nil {
+			return nil, fmt.Errorf("failed to set key ID for %s: %w", kid, err)
+		}
+
+		if err := key.Set(jwk.KeyUsageKey, jwk.ForSignature); err != nil {
+			return nil, fmt.Errorf("failed to set key usage for %s: %w", kid, err)
+		}
+
+		if err := key.Set(jwk.AlgorithmKey, managed.signer.SigningMethod().Alg()); err != nil {
+			return nil, fmt.Errorf("failed to set algorithm for %s: %w", kid, err)
+		}
+
+		if err := keySet.AddKey(key); err != nil {
+			return nil, fmt.Errorf("failed to add key %s: %w", kid, err)
+		}
+	}
+
+	return keySet, nil
+}
+
+// JWKSHandler returns an http.Handler that serves the public JWKS
+func (m *KeyManager) JWKSHandler() http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			w.Header().Set("Allow", "GET, HEAD")
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+
+			return
+		}
+
+		keySet, err := m.PublicJWKS()
+		if err != nil {
+			http.Error(w, "failed to build JWKS", http.StatusInternalServerError)
+
+			return
+		}
+
+		body, err := json.Marshal(keySet)
+		if err != nil {
+			http.Error(w, "failed to encode JWKS", http.StatusInternalServerError)
+
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/jwk-set+json")
+		w.Header().Set("Cache-Control", "public, max-age="+strconv.Itoa(int(m.jwksMaxAge.Seconds())))
+		w.WriteHeader(http.StatusOK)
+
+		if r.Method == http.MethodGet {
+			_, _ = w.Write(body)
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package signing
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/lestrrat-go/jwx/v2/jwk"
+)
+
+func newManagedTestKey(t *testing.T) *ecdsa.PrivateKey {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+
+	return key
+}
+
+func publishedKeyIDs(t *testing.T, handler http.Handler) []string {
+	t.Helper()
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jwks.json", nil))
+
+	if rec.Code != http.StatusOK {
+		t.Fatalf("JWKS handler returned status %d", rec.Code)
+	}
+
+	keySet, err := jwk.Parse(rec.Body.Bytes())
+	if err != nil {
+		t.Fatalf("failed to parse served JWKS: %v", err)
+	}
+
+	kids := make([]string, 0, keySet.Len())
+	for i := 0; i < keySet.Len(); i++ {
+		key, _ := keySet.Key(i)
+		kids = append(kids, key.KeyID())
+	}
+
+	sort.Strings(kids)
+
+	return kids
+}
+
+func signedKeyID(t *testing.T, m *KeyManager) string {
+	t.Helper()
+
+	signed, err := m.Sign(jwt.MapClaims{"iss": "https://issuer.example.com"})
+	if err != nil {
+		t.Fatalf("Sign() error = %v", err)
+	}
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(signed, jwt.MapClaims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified() error = %v", err)
+	}
+
+	kid, _ := parsed.Header["kid"].(string)
+
+	return kid
+}
+
+func TestKeyManager_Rotation(t *testing.T) {
+	clock := time.Unix(1700000000, 0)
+
+	m := NewKeyManager(WithRetirementGracePeriod(time.Hour))
+	m.now = func() time.Time { return clock }
+
+	if _, err := m.Sign(jwt.MapClaims{}); err == nil {
+		t.Fatal("expected error when no key is active")
+	}
+
+	if err := m.AddKey("key-1", newManagedTestKey(t)); err != nil {
+		t.Fatalf("AddKey() error = %v", err)
+	}
+
+	if err := m.Activate("key-1"); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+
+	if kid := signedKeyID(t, m); kid != "key-1" {
+		t.Errorf("signed with %s, want key-1", kid)
+	}
+
+	// Publish the next key ahead of the rotation
+	if err := m.AddKey("key-2", newManagedTestKey(t)); err != nil {
+		t.Fatalf("AddKey() error = %v", err)
+	}
+
+	handler := m.JWKSHandler()
+
+	if got := publishedKeyIDs(t, handler); len(got) != 2 {
+		t.Errorf("published keys = %v, want key-1 and key-2", got)
+	}
+
+	if err := m.Activate("key-2"); err != nil {
+		t.Fatalf("Activate() error = %v", err)
+	}
+
+	if state, _ := m.KeyState("key-1"); state != KeyStateRetiring {
+		t.Errorf("key-1 state = %s, want retiring", state)
+	}
+
+	if kid := signedKeyID(t, m); kid != "key-2" {
+		t.Errorf("signed with %s, want key-2", kid)
+	}
+
+	clock = clock.Add(30 * time.Minute)
+
+	if got := publishedKeyIDs(t, handler); len(got) != 2 {
+		t.Errorf("published keys during grace period = %v, want key-1 and key-2", got)
+	}
+
+	clock = clock.Add(time.Hour)
+
+	if got := publishedKeyIDs(t, handler); len(got) != 1 || got[0] != "key-2" {
+		t.Errorf("published keys after grace period = %v, want [key-2]", got)
+	}
+
+	m.Prune()
+
+	if _, ok := m.KeyState("key-1"); ok {
+		t.Error("expected key-1 to be pruned")
+	}
+
+	if err := m.Activate("key-1"); err == nil {
+		t.Error("expected error activating a removed key")
+	}
+}
+
+func TestKeyManager_JWKSHandlerHeaders(t *testing.T) {
+	m := NewKeyManager(WithJWKSMaxAge(time.Minute))
+
+	if err := m.AddKey("key-1", newManagedTestKey(t)); err != nil {
+		t.Fatalf("AddKey() error = %v", err)
+	}
+
+	if err := m.AddKey("key-1", newManagedTestKey(t)); err == nil {
+		t.Error("expected error adding a duplicate key ID")
+	}
+
+	rec := httptest.NewRecorder()
+	m.JWKSHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/jwks.json", nil))
+
+	if got := rec.Header().Get("Cache-Control"); got != "public, max-age=60" {
+		t.Errorf("Cache-Control = %q", got)
+	}
+
+	var body struct {
+		Keys []map[string]interface{} `json:"keys"`
+	}
+
+	if err := json.Unmarshal(rec.Body.Bytes(), &body); err != nil {
+		t.Fatalf("failed to decode JWKS: %v", err)
+	}
+
+	if len(body.Keys) != 1 || body.Keys[0]["alg"] != "ES256" || body.Keys[0]["use"] != "sig" {
+		t.Errorf("unexpected JWKS: %s", rec.Body.String())
+	}
+
+	if _, ok := body.Keys[0]["d"]; ok {
+		t.Error("JWKS must not contain private key material")
+	}
+
+	rec = httptest.NewRecorder()
+	m.JWKSHandler().ServeHTTP(rec, httptest.NewRequest(http.MethodPost, "/jwks.json", nil))
+
+	if rec.Code != http.StatusMethodNotAllowed {
+		t.Errorf("POST status = %d, want %d", rec.Code, http.StatusMethodNotAllowed)
+	}
+}
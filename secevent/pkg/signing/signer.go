@@ -3,6 +3,7 @@ package signing
 import (
 	"crypto"
 	"crypto/ecdsa"
+	"crypto/ed25519"
 	"crypto/rsa"
 	"fmt"
 
@@ -19,6 +20,8 @@ type DefaultSigner struct {
 	signingKey    crypto.PrivateKey
 	signingMethod jwt.SigningMethod
 	keyID         *string
+	encryption    *encryptionConfig
+	encryptionKID *string
 }
 
 // SignerOption defines the function signature for signer options
@@ -30,6 +33,9 @@ func WithKeyID(kid string) SignerOption {
 	}
 }
 
+// WithSigningMethod overrides the signing method derived from the key, e.g. to use
+// jwt.SigningMethodPS256 with an RSA key. NewSigner returns an error if the method does not
+// match the key type.
 func WithSigningMethod(method jwt.SigningMethod) SignerOption {
 	return func(s *DefaultSigner) {
 		s.signingMethod = method
@@ -37,23 +43,41 @@ func WithSigningMethod(method jwt.SigningMethod) SignerOption {
 }
 
 func NewSigner(signingKey crypto.PrivateKey, opts ...SignerOption) (*DefaultSigner, error) {
-	signer := &DefaultSigner{
-		signingKey: signingKey,
+	signingKey = normalizeSigningKey(signingKey)
+
+	signingMethod, err := SigningMethodForKey(signingKey)
+	if err != nil {
+		return nil, err
 	}
 
-	switch k := signingKey.(type) {
-	case *rsa.PrivateKey:
-		signer.signingMethod = jwt.SigningMethodRS256
-	case *ecdsa.PrivateKey:
-		signer.signingMethod = jwt.SigningMethodES256
-	default:
-		return nil, fmt.Errorf("unsupported key type: %T", k)
+	switch signingKey.(type) {
+	case *rsa.PublicKey, *ecdsa.PublicKey, ed25519.PublicKey, *ed25519.PublicKey:
+		return nil, fmt.Errorf("signing requires a private key, got %T", signingKey)
+	}
+
+	signer := &DefaultSigner{
+		signingKey:    signingKey,
+		signingMethod: signingMethod,
 	}
 
 	for _, opt := range opts {
 		opt(signer)
 	}
 
+	if err := checkSigningMethod(signer.signingMethod, signer.signingKey); err != nil {
+		return nil, err
+	}
+
+	if signer.encryption != nil {
+		signer.encryption.keyID = signer.encryptionKID
+
+		if err := signer.encryption.validate(); err != nil {
+			return nil, fmt.Errorf("invalid encryption configuration: %w", err)
+		}
+	} else if signer.encryptionKID != nil {
+		return nil, fmt.Errorf("encryption key ID is set but encryption is not enabled")
+	}
+
 	return signer, nil
 }
 
@@ -66,7 +90,16 @@ func (s *DefaultSigner) Sign(claims jwt.Claims) (string, error) {
 
 	token.Header["typ"] = "secevent+jwt"
 
-	return token.SignedString(s.signingKey)
+	signedToken, err := token.SignedString(s.signingKey)
+	if err != nil {
+		return "", err
+	}
+
+	if s.encryption != nil {
+		return s.encryption.encrypt(signedToken)
+	}
+
+	return signedToken, nil
 }
 
 func (s *DefaultSigner) SigningKey() crypto.PrivateKey {
@@ -0,0 +1,168 @@
+package signing_test
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/builder"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/parser"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/signing"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+func newTestSecEvent(t *testing.T) *token.SecEvent {
+	t.Helper()
+
+	userEmail, err := subject.NewEmailSubject("user@example.com")
+	if err != nil {
+		t.Fatalf("NewEmailSubject() error = %v", err)
+	}
+
+	return builder.NewBuilder(builder.WithDefaultIssuer("https://issuer.example.com")).
+		NewSecEvent().
+		WithAudience("https://receiver.example.com").
+		WithSubject(userEmail).
+		WithEvent(caep.NewSessionRevokedEvent())
+}
+
+func headerAlg(t *testing.T, signed string) string {
+	t.Helper()
+
+	parsed, _, err := jwt.NewParser().ParseUnverified(signed, jwt.MapClaims{})
+	if err != nil {
+		t.Fatalf("ParseUnverified() error = %v", err)
+	}
+
+	alg, _ := parsed.Header["alg"].(string)
+
+	return alg
+}
+
+func TestNewSigner_AlgorithmFromKey(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+
+	newECKey := func(curve elliptic.Curve) *ecdsa.PrivateKey {
+		key, err := ecdsa.GenerateKey(curve, rand.Reader)
+		if err != nil {
+			t.Fatalf("failed to generate EC key: %v", err)
+		}
+
+		return key
+	}
+
+	tests := []struct {
+		name    string
+		key     crypto.Signer
+		opts    []signing.SignerOption
+		wantAlg string
+	}{
+		{name: "RSA", key: rsaKey, wantAlg: "RS256"},
+		{name: "RSA-PSS", key: rsaKey, opts: []signing.SignerOption{signing.WithSigningMethod(jwt.SigningMethodPS384)}, wantAlg: "PS384"},
+		{name: "P-256", key: newECKey(elliptic.P256()), wantAlg: "ES256"},
+		{name: "P-384", key: newECKey(elliptic.P384()), wantAlg: "ES384"},
+		{name: "P-521", key: newECKey(elliptic.P521()), wantAlg: "ES512"},
+		{name: "Ed25519", key: edKey, wantAlg: "EdDSA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]signing.SignerOption{signing.WithKeyID("key-1")}, tt.opts...)
+
+			signer, err := signing.NewSigner(tt.key, opts...)
+			if err != nil {
+				t.Fatalf("NewSigner() error = %v", err)
+			}
+
+			signed, err := signer.Sign(newTestSecEvent(t))
+			if err != nil {
+				t.Fatalf("Sign() error = %v", err)
+			}
+
+			if alg := headerAlg(t, signed); alg != tt.wantAlg {
+				t.Errorf("alg = %s, want %s", alg, tt.wantAlg)
+			}
+
+			p := parser.NewParser(parser.WithPublicKey(tt.key.Public(), "key-1"))
+			if _, err := p.ParseSecEvent(signed); err != nil {
+				t.Errorf("ParseSecEvent() error = %v", err)
+			}
+
+			restricted := parser.NewParser(
+				parser.WithPublicKey(tt.key.Public(), "key-1"),
+				parser.WithAllowedAlgorithms("none-of-the-above"),
+			)
+			if _, err := restricted.ParseSecEvent(signed); err == nil {
+				t.Error("expected algorithm outside the allowlist to be rejected")
+			}
+		})
+	}
+}
+
+func TestNewSigner_RejectsUnsupportedKeys(t *testing.T) {
+	ecKey, err := ecdsa.GenerateKey(elliptic.P224(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	if _, err := signing.NewSigner(ecKey); err == nil {
+		t.Error("expected P-224 key to be rejected")
+	}
+
+	if _, err := signing.NewSigner(&ecKey.PublicKey); err == nil {
+		t.Error("expected public key to be rejected")
+	}
+}
+
+func TestNewSigner_RejectsMismatchedSigningMethod(t *testing.T) {
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate RSA key: %v", err)
+	}
+
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate EC key: %v", err)
+	}
+
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatalf("failed to generate Ed25519 key: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		key    crypto.Signer
+		method jwt.SigningMethod
+	}{
+		{name: "RSA with ES256", key: rsaKey, method: jwt.SigningMethodES256},
+		{name: "RSA with EdDSA", key: rsaKey, method: jwt.SigningMethodEdDSA},
+		{name: "P-256 with RS256", key: ecKey, method: jwt.SigningMethodRS256},
+		{name: "P-256 with ES384", key: ecKey, method: jwt.SigningMethodES384},
+		{name: "Ed25519 with ES256", key: edKey, method: jwt.SigningMethodES256},
+		{name: "RSA with HS256", key: rsaKey, method: jwt.SigningMethodHS256},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := signing.NewSigner(tt.key, signing.WithSigningMethod(tt.method)); err == nil {
+				t.Errorf("expected %s to be rejected for %T", tt.method.Alg(), tt.key)
+			}
+		})
+	}
+}
@@ -0,0 +1,182 @@
+package subject
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// AliasesSubject represents a principal identified by several identifiers, any of which
+// refers to the same principal. Identifiers must not themselves be aliases subjects.
+type AliasesSubject struct {
+	format      Format
+	identifiers []Subject
+}
+
+// NewAliasesSubject creates an aliases subject from the given identifiers
+func NewAliasesSubject(identifiers ...Subject) (*AliasesSubject, error) {
+	s := &AliasesSubject{
+		format:      FormatAliases,
+		identifiers: append([]Subject(nil), identifiers...),
+	}
+
+	if err := s.Validate(); err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Format returns the format of the subject identifier
+func (s *AliasesSubject) Format() Format {
+	return s.format
+}
+
+// WithIdentifier adds an identifier to the aliases
+func (s *AliasesSubject) WithIdentifier(subject Subject) *AliasesSubject {
+	if subject != nil {
+		s.identifiers = append(s.identifiers, subject)
+	}
+
+	return s
+}
+
+// Identifiers returns the identifiers of the principal
+func (s *AliasesSubject) Identifiers() []Subject {
+	return append([]Subject(nil), s.identifiers...)
+}
+
+// Contains reports whether any of the identifiers is equal to the given subject
+func (s *AliasesSubject) Contains(subject Subject) bool {
+	for _, identifier := range s.identifiers {
+		if Equal(identifier, subject) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// ContainsFormat reports whether any of the identifiers has the given format
+func (s *AliasesSubject) ContainsFormat(format Format) bool {
+	_, ok := s.IdentifierByFormat(format)
+
+	return ok
+}
+
+// IdentifierByFormat returns the first identifier with the given format
+func (s *AliasesSubject) IdentifierByFormat(format Format) (Subject, bool) {
+	for _, identifier := range s.identifiers {
+		if identifier.Format() == format {
+			return identifier, true
+		}
+	}
+
+	return nil, false
+}
+
+// Validate ensures the aliases subject has at least one valid, non-aliases identifier
+func (s *AliasesSubject) Validate() error {
+	if s.format != FormatAliases {
+		return NewError(ErrCodeInvalidFormat, "invalid format for aliases subject", "format")
+	}
+
+	if len(s.identifiers) == 0 {
+		return NewError(ErrCodeMissingValue, "aliases subject must have at least one identifier", "identifiers")
+	}
+
+	for i, identifier := range s.identifiers {
+		if identifier == nil {
+			return NewError(ErrCodeMissingValue, fmt.Sprintf("identifier %d is empty", i), "identifiers")
+		}
+
+		if identifier.Format() == FormatAliases {
+			return NewError(ErrCodeInvalidValue, "aliases subject must not contain nested aliases", "identifiers")
+		}
+
+		if err := identifier.Validate(); err != nil {
+			return NewError(ErrCodeInvalidValue, fmt.Sprintf("invalid identifier %d: %v", i, err), "identifiers")
+		}
+	}
+
+	return nil
+}
+
+// MarshalJSON implements the json.Marshaler interface
+func (s *AliasesSubject) MarshalJSON() ([]byte, error) {
+	identifiers := s.identifiers
+	if identifiers == nil {
+		identifiers = []Subject{}
+	}
+
+	return json.Marshal(struct {
+		Format      Format    `json:"format"`
+		Identifiers []Subject `json:"identifiers"`
+	}{
+		Format:      s.format,
+		Identifiers: identifiers,
+	})
+}
+
+// UnmarshalJSON implements the json.Unmarshaler interface
+func (s *AliasesSubject) UnmarshalJSON(data []byte) error {
+	temp := struct {
+		Format      Format            `json:"format"`
+		Identifiers []json.RawMessage `json:"identifiers"`
+	}{}
+
+	if err := json.Unmarshal(data, &temp); err != nil {
+		return err
+	}
+
+	if temp.Format != FormatAliases {
+		return NewError(ErrCodeInvalidFormat, "invalid format for aliases subject", "format")
+	}
+
+	identifiers := make([]Subject, 0, len(temp.Identifiers))
+
+	for i, raw := range temp.Identifiers {
+		var formatObj struct {
+			Format Format `json:"format"`
+		}
+
+		if err := json.Unmarshal(raw, &formatObj); err != nil {
+			return fmt.Errorf("failed to parse identifier %d: %w", i, err)
+		}
+
+		// Checked before parsing so deeply nested input is rejected without recursion
+		if formatObj.Format == FormatAliases {
+			return NewError(ErrCodeInvalidValue, "aliases subject must not contain nested aliases", "identifiers")
+		}
+
+		identifier, err := ParseSubject(raw)
+		if err != nil {
+			return fmt.Errorf("failed to parse identifier %d: %w", i, err)
+		}
+
+		identifiers = append(identifiers, identifier)
+	}
+
+	s.format = temp.Format
+	s.identifiers = identifiers
+
+	return nil
+}
+
+// Payload returns the subject's payload as a map[string]interface{}
+func (s *AliasesSubject) Payload() (map[string]interface{}, error) {
+	identifiers := make([]interface{}, 0, len(s.identifiers))
+
+	for i, identifier := range s.identifiers {
+		payload, err := identifier.Payload()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get identifier %d payload: %w", i, err)
+		}
+
+		identifiers = append(identifiers, payload)
+	}
+
+	return map[string]interface{}{
+		"format":      string(s.format),
+		"identifiers": identifiers,
+	}, nil
+}
@@ -0,0 +1,92 @@
+package subject
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+// Simple subjects marshal with sorted keys
+const aliasesJSON = `{"format":"aliases","identifiers":[{"email":"user@example.com","format":"email"},{"format":"phone_number","phone_number":"+12065550100"},{"format":"iss_sub","issuer":"https://issuer.example.com","sub":"1234"}]}`
+
+func TestAliasesSubject_RoundTrip(t *testing.T) {
+	parsed, err := ParseSubject([]byte(aliasesJSON))
+	if err != nil {
+		t.Fatalf("ParseSubject() error = %v", err)
+	}
+
+	aliases, ok := parsed.(*AliasesSubject)
+	if !ok {
+		t.Fatalf("ParseSubject() returned %T, want *AliasesSubject", parsed)
+	}
+
+	if err := aliases.Validate(); err != nil {
+		t.Errorf("Validate() error = %v", err)
+	}
+
+	if n := len(aliases.Identifiers()); n != 3 {
+		t.Errorf("len(Identifiers()) = %d, want 3", n)
+	}
+
+	marshaled, err := json.Marshal(aliases)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if string(marshaled) != aliasesJSON {
+		t.Errorf("round trip = %s, want %s", marshaled, aliasesJSON)
+	}
+}
+
+func TestAliasesSubject_Contains(t *testing.T) {
+	email, _ := NewEmailSubject("user@example.com")
+	otherEmail, _ := NewEmailSubject("other@example.com")
+	opaque, _ := NewOpaqueSubject("user@example.com")
+	issSub, _ := NewIssuerSubSubject("https://issuer.example.com", "1234")
+
+	aliases, err := NewAliasesSubject(email, issSub)
+	if err != nil {
+		t.Fatalf("NewAliasesSubject() error = %v", err)
+	}
+
+	if !aliases.Contains(email) || !aliases.Contains(issSub) {
+		t.Error("expected aliases to contain its identifiers")
+	}
+
+	if aliases.Contains(otherEmail) || aliases.Contains(opaque) || aliases.Contains(nil) {
+		t.Error("unexpected match")
+	}
+
+	if !aliases.ContainsFormat(FormatIssuerSub) || aliases.ContainsFormat(FormatPhone) {
+		t.Error("unexpected ContainsFormat result")
+	}
+
+	if identifier, ok := aliases.IdentifierByFormat(FormatEmail); !ok || identifier != email {
+		t.Errorf("IdentifierByFormat() = %v, %v", identifier, ok)
+	}
+}
+
+func TestAliasesSubject_Validation(t *testing.T) {
+	email, _ := NewEmailSubject("user@example.com")
+
+	if _, err := NewAliasesSubject(); err == nil {
+		t.Error("expected error for empty aliases")
+	}
+
+	inner, err := NewAliasesSubject(email)
+	if err != nil {
+		t.Fatalf("NewAliasesSubject() error = %v", err)
+	}
+
+	if _, err := NewAliasesSubject(email, inner); err == nil {
+		t.Error("expected error for nested aliases")
+	}
+
+	nested := `{"format":"aliases","identifiers":[{"format":"aliases","identifiers":[{"format":"email","email":"user@example.com"}]}]}`
+	if _, err := ParseSubject([]byte(nested)); err == nil {
+		t.Error("expected error parsing nested aliases")
+	}
+
+	if _, err := ParseSubject([]byte(`{"format":"aliases","identifiers":[{"format":"unknown"}]}`)); err == nil {
+		t.Error("expected error for unknown identifier format")
+	}
+}
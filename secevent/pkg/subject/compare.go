@@ -0,0 +1,241 @@
+package subject
+
+import (
+	"encoding/json"
+	"net/url"
+	"sort"
+	"strings"
+)
+
+// Canonical returns a stable string key for the subject, suitable for map lookups and
+// deduplication. Subjects that refer to the same principal have the same key:
+// email addresses compare case-insensitively, phone numbers ignore formatting characters,
+// URI schemes and hosts are lowercased, and aliases ignore identifier order and duplicates.
+// It returns an empty string for a nil subject or one whose payload cannot be produced.
+func Canonical(s Subject) string {
+	if s == nil {
+		return ""
+	}
+
+	normalized, err := normalize(s)
+	if err != nil {
+		return ""
+	}
+
+	key, err := json.Marshal(normalized)
+	if err != nil {
+		return ""
+	}
+
+	return string(key)
+}
+
+// Equal reports whether a and b identify the same principal in the same way.
+// Unlike Matches, it requires both subjects to have the same format and structure.
+func Equal(a, b Subject) bool {
+	if a == nil || b == nil {
+		return a == nil && b == nil
+	}
+
+	keyA := Canonical(a)
+
+	return keyA != "" && keyA == Canonical(b)
+}
+
+// Matches reports whether candidate is selected by pattern:
+//   - a complex pattern matches a complex candidate when every component present in the
+//     pattern matches the candidate's component of the same type; the candidate may carry
+//     additional components
+//   - a simple pattern matches a complex candidate when it matches any of its components
+//   - an aliases pattern or candidate matches when any of its identifiers matches
+//   - otherwise, the subjects must be Equal
+func Matches(pattern, candidate Subject) bool {
+	if pattern == nil || candidate == nil {
+		return false
+	}
+
+	if aliases, ok := pattern.(*AliasesSubject); ok {
+		for _, identifier := range aliases.identifiers {
+			if Matches(identifier, candidate) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	if aliases, ok := candidate.(*AliasesSubject); ok {
+		for _, identifier := range aliases.identifiers {
+			if Matches(pattern, identifier) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	candidateComplex, candidateIsComplex := candidate.(ComplexSubject)
+
+	if patternComplex, ok := pattern.(ComplexSubject); ok {
+		if !candidateIsComplex {
+			return false
+		}
+
+		candidateComponents := complexComponents(candidateComplex)
+
+		for componentType, component := range complexComponents(patternComplex) {
+			candidateComponent, ok := candidateComponents[componentType]
+			if !ok || !Matches(component, candidateComponent) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	if candidateIsComplex {
+		for _, component := range complexComponents(candidateComplex) {
+			if Matches(pattern, component) {
+				return true
+			}
+		}
+
+		return false
+	}
+
+	return Equal(pattern, candidate)
+}
+
+// complexComponents returns the components present in a complex subject keyed by type
+func complexComponents(s ComplexSubject) map[ComponentType]Subject {
+	components := make(map[ComponentType]Subject)
+
+	for componentType, getter := range map[ComponentType]func() (Subject, bool){
+		ComponentUser:        s.UserComponent,
+		ComponentDevice:      s.DeviceComponent,
+		ComponentSession:     s.SessionComponent,
+		ComponentApplication: s.ApplicationComponent,
+		ComponentTenant:      s.TenantComponent,
+		ComponentOrgUnit:     s.OrgUnitComponent,
+		ComponentGroup:       s.GroupComponent,
+	} {
+		if component, ok := getter(); ok {
+			components[componentType] = component
+		}
+	}
+
+	return components
+}
+
+// normalize returns a JSON-marshalable representation of the subject in which
+// equivalent identifiers are identical
+func normalize(s Subject) (interface{}, error) {
+	switch v := s.(type) {
+	case *EmailSubject:
+		return map[string]string{
+			"format": string(FormatEmail),
+			"email":  strings.ToLower(strings.TrimSpace(v.email)),
+		}, nil
+	case *PhoneSubject:
+		return map[string]string{
+			"format":       string(FormatPhone),
+			"phone_number": normalizePhone(v.phone),
+		}, nil
+	case *URISubject:
+		return map[string]string{
+			"format": string(FormatURI),
+			"uri":    normalizeURI(v.uri),
+		}, nil
+	case *AccountSubject:
+		return map[string]string{
+			"format": string(FormatAccount),
+			"uri":    normalizeAccount(v.uri),
+		}, nil
+	case *AliasesSubject:
+		keys := make([]string, 0, len(v.identifiers))
+		seen := make(map[string]bool)
+
+		for _, identifier := range v.identifiers {
+			key := Canonical(identifier)
+			if !seen[key] {
+				seen[key] = true
+				keys = append(keys, key)
+			}
+		}
+
+		sort.Strings(keys)
+
+		identifiers := make([]json.RawMessage, 0, len(keys))
+		for _, key := range keys {
+			identifiers = append(identifiers, json.RawMessage(key))
+		}
+
+		return map[string]interface{}{
+			"format":      string(FormatAliases),
+			"identifiers": identifiers,
+		}, nil
+	case ComplexSubject:
+		normalized := map[string]interface{}{
+			"format": string(FormatComplex),
+		}
+
+		for componentType, component := range complexComponents(v) {
+			componentNormalized, err := normalize(component)
+			if err != nil {
+				return nil, err
+			}
+
+			normalized[string(componentType)] = componentNormalized
+		}
+
+		return normalized, nil
+	default:
+		return s.Payload()
+	}
+}
+
+// normalizePhone strips visual separators, keeping digits and a leading '+'
+func normalizePhone(phone string) string {
+	var b strings.Builder
+
+	for i, r := range strings.TrimSpace(phone) {
+		switch {
+		case r >= '0' && r <= '9':
+			b.WriteRune(r)
+		case r == '+' && i == 0:
+			b.WriteRune(r)
+		}
+	}
+
+	return b.String()
+}
+
+// normalizeURI lowercases the scheme and host of a URI
+func normalizeURI(uri string) string {
+	uri = strings.TrimSpace(uri)
+
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return uri
+	}
+
+	parsed.Scheme = strings.ToLower(parsed.Scheme)
+	parsed.Host = strings.ToLower(parsed.Host)
+
+	return parsed.String()
+}
+
+// normalizeAccount lowercases the scheme and the host part of an acct URI
+func normalizeAccount(uri string) string {
+	uri = strings.TrimSpace(uri)
+
+	if len(uri) >= len("acct:") && strings.EqualFold(uri[:len("acct:")], "acct:") {
+		uri = "acct:" + uri[len("acct:"):]
+	}
+
+	if at := strings.LastIndex(uri, "@"); at >= 0 {
+		uri = uri[:at] + strings.ToLower(uri[at:])
+	}
+
+	return uri
+}
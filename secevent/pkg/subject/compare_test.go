@@ -0,0 +1,153 @@
+package subject
+
+import "testing"
+
+func mustParse(t *testing.T, data string) Subject {
+	t.Helper()
+
+	s, err := ParseSubject([]byte(data))
+	if err != nil {
+		t.Fatalf("ParseSubject(%s) error = %v", data, err)
+	}
+
+	return s
+}
+
+func TestEqualAndCanonical(t *testing.T) {
+	tests := []struct {
+		name  string
+		a, b  string
+		equal bool
+	}{
+		{
+			name:  "email ignores case",
+			a:     `{"format":"email","email":"User@Example.COM"}`,
+			b:     `{"format":"email","email":"user@example.com"}`,
+			equal: true,
+		},
+		{
+			name:  "phone ignores formatting",
+			a:     `{"format":"phone_number","phone_number":"+1 (206) 555-0100"}`,
+			b:     `{"format":"phone_number","phone_number":"+12065550100"}`,
+			equal: true,
+		},
+		{
+			name:  "uri host is case-insensitive",
+			a:     `{"format":"uri","uri":"HTTPS://Example.com/Users/1"}`,
+			b:     `{"format":"uri","uri":"https://example.com/Users/1"}`,
+			equal: true,
+		},
+		{
+			name:  "uri path is case-sensitive",
+			a:     `{"format":"uri","uri":"https://example.com/users/1"}`,
+			b:     `{"format":"uri","uri":"https://example.com/Users/1"}`,
+			equal: false,
+		},
+		{
+			name:  "opaque is exact",
+			a:     `{"format":"opaque","id":"ABC"}`,
+			b:     `{"format":"opaque","id":"abc"}`,
+			equal: false,
+		},
+		{
+			name:  "same value in different formats",
+			a:     `{"format":"opaque","id":"user@example.com"}`,
+			b:     `{"format":"email","email":"user@example.com"}`,
+			equal: false,
+		},
+		{
+			name:  "complex compares components",
+			a:     `{"format":"complex","user":{"format":"email","email":"USER@example.com"},"device":{"format":"opaque","id":"d1"}}`,
+			b:     `{"format":"complex","device":{"format":"opaque","id":"d1"},"user":{"format":"email","email":"user@example.com"}}`,
+			equal: true,
+		},
+		{
+			name:  "complex with extra component",
+			a:     `{"format":"complex","user":{"format":"email","email":"user@example.com"}}`,
+			b:     `{"format":"complex","user":{"format":"email","email":"user@example.com"},"device":{"format":"opaque","id":"d1"}}`,
+			equal: false,
+		},
+		{
+			name:  "aliases ignore order and duplicates",
+			a:     `{"format":"aliases","identifiers":[{"format":"email","email":"user@example.com"},{"format":"opaque","id":"u1"}]}`,
+			b:     `{"format":"aliases","identifiers":[{"format":"opaque","id":"u1"},{"format":"email","email":"USER@example.com"},{"format":"opaque","id":"u1"}]}`,
+			equal: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a, b := mustParse(t, tt.a), mustParse(t, tt.b)
+
+			if got := Equal(a, b); got != tt.equal {
+				t.Errorf("Equal() = %v, want %v", got, tt.equal)
+			}
+
+			if got := Canonical(a) == Canonical(b); got != tt.equal {
+				t.Errorf("Canonical keys equal = %v, want %v (%s vs %s)", got, tt.equal, Canonical(a), Canonical(b))
+			}
+		})
+	}
+
+	if !Equal(nil, nil) || Equal(mustParse(t, `{"format":"opaque","id":"x"}`), nil) {
+		t.Error("unexpected nil handling")
+	}
+}
+
+func TestMatches(t *testing.T) {
+	candidate := mustParse(t, `{"format":"complex","user":{"format":"email","email":"user@example.com"},"device":{"format":"opaque","id":"d1"},"tenant":{"format":"opaque","id":"t1"}}`)
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    bool
+	}{
+		{
+			name:    "partial complex pattern",
+			pattern: `{"format":"complex","user":{"format":"email","email":"USER@example.com"},"tenant":{"format":"opaque","id":"t1"}}`,
+			want:    true,
+		},
+		{
+			name:    "complex pattern with mismatched component",
+			pattern: `{"format":"complex","user":{"format":"email","email":"user@example.com"},"device":{"format":"opaque","id":"d2"}}`,
+			want:    false,
+		},
+		{
+			name:    "complex pattern with component missing from candidate",
+			pattern: `{"format":"complex","session":{"format":"opaque","id":"s1"}}`,
+			want:    false,
+		},
+		{
+			name:    "simple pattern matches a component",
+			pattern: `{"format":"opaque","id":"d1"}`,
+			want:    true,
+		},
+		{
+			name:    "simple pattern matching no component",
+			pattern: `{"format":"opaque","id":"nope"}`,
+			want:    false,
+		},
+		{
+			name:    "aliases pattern matches through any identifier",
+			pattern: `{"format":"aliases","identifiers":[{"format":"opaque","id":"nope"},{"format":"email","email":"user@example.com"}]}`,
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := Matches(mustParse(t, tt.pattern), candidate); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	aliasesCandidate := mustParse(t, `{"format":"aliases","identifiers":[{"format":"opaque","id":"u1"},{"format":"email","email":"user@example.com"}]}`)
+	if !Matches(mustParse(t, `{"format":"email","email":"user@example.com"}`), aliasesCandidate) {
+		t.Error("expected simple pattern to match aliases candidate")
+	}
+
+	if Matches(candidate, mustParse(t, `{"format":"email","email":"user@example.com"}`)) {
+		t.Error("complex pattern must not match a simple candidate")
+	}
+}
@@ -0,0 +1,37 @@
+package subject
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func FuzzParseSubject(f *testing.F) {
+	f.Add([]byte(`{"format":"email","email":"user@example.com"}`))
+	f.Add([]byte(`{"format":"phone_number","phone_number":"+12065550100"}`))
+	f.Add([]byte(`{"format":"iss_sub","issuer":"https://issuer.example.com","sub":"user-123"}`))
+	f.Add([]byte(`{"format":"opaque","id":"11112222333344445555"}`))
+	f.Add([]byte(`{"format":"account","uri":"acct:user@example.com"}`))
+	f.Add([]byte(`{"format":"complex","user":{"format":"email","email":"user@example.com"},"device":{"format":"opaque","id":"d-1"}}`))
+	f.Add([]byte(`{"format":"aliases","identifiers":[{"format":"email","email":"user@example.com"},{"format":"opaque","id":"x"}]}`))
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		sub, err := ParseSubject(data)
+		if err != nil {
+			return
+		}
+
+		marshaled, err := json.Marshal(sub)
+		if err != nil {
+			t.Fatalf("failed to marshal parsed subject: %v", err)
+		}
+
+		reparsed, err := ParseSubject(marshaled)
+		if err != nil {
+			t.Fatalf("failed to parse marshaled subject %s: %v", marshaled, err)
+		}
+
+		if !Equal(sub, reparsed) {
+			t.Errorf("round trip changed subject: %s", marshaled)
+		}
+	})
+}
@@ -20,6 +20,7 @@ const (
 	FormatJWTID     Format = "jwt_id"
 	FormatSAMLID    Format = "saml_assertion_id"
 	FormatComplex   Format = "complex"
+	FormatAliases   Format = "aliases"
 )
 
 // ComponentType represents the type of a complex subject component
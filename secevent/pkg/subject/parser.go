@@ -3,8 +3,71 @@ package subject
 import (
 	"encoding/json"
 	"fmt"
+	"sync"
 )
 
+// FormatParser parses the JSON representation of a subject identifier
+type FormatParser func(data []byte) (Subject, error)
+
+var (
+	formatRegistryMu sync.RWMutex
+	formatRegistry   = map[Format]FormatParser{}
+)
+
+func init() {
+	for _, format := range []Format{
+		FormatAccount,
+		FormatEmail,
+		FormatIssuerSub,
+		FormatOpaque,
+		FormatPhone,
+		FormatDID,
+		FormatURI,
+		FormatJWTID,
+		FormatSAMLID,
+	} {
+		RegisterSubjectFormat(format, func(data []byte) (Subject, error) {
+			return ParseSimpleSubject(data)
+		})
+	}
+
+	RegisterSubjectFormat(FormatComplex, func(data []byte) (Subject, error) {
+		return ParseComplexSubject(data)
+	})
+
+	RegisterSubjectFormat(FormatAliases, func(data []byte) (Subject, error) {
+		return ParseAliasesSubject(data)
+	})
+}
+
+// RegisterSubjectFormat registers a parser for a subject format, replacing any existing parser.
+// Use it to support custom or vendor-specific formats in ParseSubject.
+func RegisterSubjectFormat(format Format, parser FormatParser) {
+	formatRegistryMu.Lock()
+	defer formatRegistryMu.Unlock()
+
+	formatRegistry[format] = parser
+}
+
+// IsSubjectFormatRegistered checks if a parser is registered for the given format
+func IsSubjectFormatRegistered(format Format) bool {
+	formatRegistryMu.RLock()
+	defer formatRegistryMu.RUnlock()
+
+	_, ok := formatRegistry[format]
+
+	return ok
+}
+
+func getFormatParser(format Format) (FormatParser, bool) {
+	formatRegistryMu.RLock()
+	defer formatRegistryMu.RUnlock()
+
+	parser, ok := formatRegistry[format]
+
+	return parser, ok
+}
+
 // ParseSimpleSubject parses a JSON object into the appropriate simple subject type
 func ParseSimpleSubject(data []byte) (SimpleSubject, error) {
 	var raw map[string]string
@@ -12,46 +75,37 @@ func ParseSimpleSubject(data []byte) (SimpleSubject, error) {
 		return nil, fmt.Errorf("failed to parse subject: %w", err)
 	}
 
+	var subject SimpleSubject
+
 	format := Format(raw["format"])
 	switch format {
+	case FormatAccount:
+		subject = &AccountSubject{}
 	case FormatEmail:
-		var subject EmailSubject
-		if err := json.Unmarshal(data, &subject); err != nil {
-			return nil, err
-		}
-
-		return &subject, nil
-	case FormatPhone:
-		var subject PhoneSubject
-		if err := json.Unmarshal(data, &subject); err != nil {
-			return nil, err
-		}
-
-		return &subject, nil
+		subject = &EmailSubject{}
 	case FormatIssuerSub:
-		var subject IssuerSubSubject
-		if err := json.Unmarshal(data, &subject); err != nil {
-			return nil, err
-		}
-
-		return &subject, nil
-	case FormatURI:
-		var subject URISubject
-		if err := json.Unmarshal(data, &subject); err != nil {
-			return nil, err
-		}
-
-		return &subject, nil
+		subject = &IssuerSubSubject{}
 	case FormatOpaque:
-		var subject OpaqueSubject
-		if err := json.Unmarshal(data, &subject); err != nil {
-			return nil, err
-		}
-
-		return &subject, nil
+		subject = &OpaqueSubject{}
+	case FormatPhone:
+		subject = &PhoneSubject{}
+	case FormatDID:
+		subject = &DIDSubject{}
+	case FormatURI:
+		subject = &URISubject{}
+	case FormatJWTID:
+		subject = &JWTIDSubject{}
+	case FormatSAMLID:
+		subject = &SAMLIDSubject{}
 	default:
 		return nil, NewError(ErrCodeInvalidFormat, "unsupported subject format", "format")
 	}
+
+	if err := json.Unmarshal(data, subject); err != nil {
+		return nil, err
+	}
+
+	return subject, nil
 }
 
 func ParseComplexSubject(data []byte) (ComplexSubject, error) {
@@ -63,6 +117,15 @@ func ParseComplexSubject(data []byte) (ComplexSubject, error) {
 	return &subject, nil
 }
 
+func ParseAliasesSubject(data []byte) (*AliasesSubject, error) {
+	var subject AliasesSubject
+	if err := json.Unmarshal(data, &subject); err != nil {
+		return nil, fmt.Errorf("failed to parse aliases subject: %w", err)
+	}
+
+	return &subject, nil
+}
+
 func ParseSubject(data []byte) (Subject, error) {
 	// First unmarshal just the format to determine the type
 	var formatObj struct {
@@ -72,17 +135,14 @@ func ParseSubject(data []byte) (Subject, error) {
 		return nil, fmt.Errorf("failed to parse subject format: %w", err)
 	}
 
-	// Based on the format, parse as either simple or complex subject
-	switch Format(formatObj.Format) {
-	case FormatComplex:
-		return ParseComplexSubject(data)
-	case FormatEmail, FormatPhone, FormatIssuerSub, FormatURI, FormatOpaque:
-		return ParseSimpleSubject(data)
-	default:
+	parser, ok := getFormatParser(Format(formatObj.Format))
+	if !ok {
 		return nil, NewError(
 			ErrCodeInvalidFormat,
 			fmt.Sprintf("unsupported subject format: %s", formatObj.Format),
 			"format",
 		)
 	}
+
+	return parser(data)
 }
@@ -0,0 +1,169 @@
+package subject
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestParseSubject_AllFormats(t *testing.T) {
+	tests := []struct {
+		name   string
+		json   string
+		format Format
+	}{
+		{"account", `{"format":"account","uri":"acct:user@example.com"}`, FormatAccount},
+		{"email", `{"format":"email","email":"user@example.com"}`, FormatEmail},
+		{"iss_sub", `{"format":"iss_sub","issuer":"https://issuer.example.com","sub":"1234"}`, FormatIssuerSub},
+		{"opaque", `{"format":"opaque","id":"11112222333344445555"}`, FormatOpaque},
+		{"phone_number", `{"format":"phone_number","phone_number":"+12065550100"}`, FormatPhone},
+		{"did", `{"format":"did","url":"did:example:123456"}`, FormatDID},
+		{"uri", `{"format":"uri","uri":"https://example.com/users/1"}`, FormatURI},
+		{"jwt_id", `{"format":"jwt_id","iss":"https://issuer.example.com","jti":"B70BA622"}`, FormatJWTID},
+		{"saml_assertion_id", `{"format":"saml_assertion_id","issuer":"https://idp.example.com","assertion_id":"_8e8dc5f69a98cc4c1ff3427e5ce34606fd672f91e6"}`, FormatSAMLID},
+		{"complex", `{"format":"complex","user":{"format":"email","email":"user@example.com"},"device":{"format":"opaque","id":"dev-1"}}`, FormatComplex},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseSubject([]byte(tt.json))
+			if err != nil {
+				t.Fatalf("ParseSubject() error = %v", err)
+			}
+
+			if parsed.Format() != tt.format {
+				t.Errorf("Format() = %s, want %s", parsed.Format(), tt.format)
+			}
+
+			if err := parsed.Validate(); err != nil {
+				t.Errorf("Validate() error = %v", err)
+			}
+
+			marshaled, err := json.Marshal(parsed)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			var got, want map[string]interface{}
+			_ = json.Unmarshal(marshaled, &got)
+			_ = json.Unmarshal([]byte(tt.json), &want)
+
+			gotJSON, _ := json.Marshal(got)
+			wantJSON, _ := json.Marshal(want)
+
+			if string(gotJSON) != string(wantJSON) {
+				t.Errorf("round trip = %s, want %s", gotJSON, wantJSON)
+			}
+		})
+	}
+}
+
+func TestParseSubject_PhoneMemberNames(t *testing.T) {
+	tests := []struct {
+		name string
+		json string
+	}{
+		{"phone_number", `{"format":"phone_number","phone_number":"+12065550100"}`},
+		{"legacy phone", `{"format":"phone_number","phone":"+12065550100"}`},
+		{"both prefers phone_number", `{"format":"phone_number","phone_number":"+12065550100","phone":"+10000000000"}`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			parsed, err := ParseSubject([]byte(tt.json))
+			if err != nil {
+				t.Fatalf("ParseSubject() error = %v", err)
+			}
+
+			if phone := parsed.(*PhoneSubject).Phone(); phone != "+12065550100" {
+				t.Errorf("Phone() = %q", phone)
+			}
+
+			marshaled, err := json.Marshal(parsed)
+			if err != nil {
+				t.Fatalf("Marshal() error = %v", err)
+			}
+
+			if want := `{"format":"phone_number","phone_number":"+12065550100"}`; string(marshaled) != want {
+				t.Errorf("Marshal() = %s, want %s", marshaled, want)
+			}
+		})
+	}
+}
+
+func TestParseSubject_UnknownFormat(t *testing.T) {
+	_, err := ParseSubject([]byte(`{"format":"unregistered-format","id":"x"}`))
+	if err == nil || !strings.Contains(err.Error(), "unsupported subject format") {
+		t.Errorf("expected unsupported format error, got %v", err)
+	}
+}
+
+// employeeIDSubject is a vendor-specific format used to exercise RegisterSubjectFormat
+type employeeIDSubject struct {
+	baseSimpleSubject
+	employeeID string
+}
+
+func (s *employeeIDSubject) Validate() error {
+	if s.employeeID == "" {
+		return NewError(ErrCodeMissingValue, "employee ID is required", "employee_id")
+	}
+
+	return nil
+}
+
+func (s *employeeIDSubject) MarshalJSON() ([]byte, error) {
+	return json.Marshal(map[string]string{"format": string(s.format), "employee_id": s.employeeID})
+}
+
+func (s *employeeIDSubject) UnmarshalJSON(data []byte) error {
+	var raw map[string]string
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	s.format = Format(raw["format"])
+	s.employeeID = raw["employee_id"]
+
+	return nil
+}
+
+func (s *employeeIDSubject) Payload() (map[string]interface{}, error) {
+	return map[string]interface{}{"format": string(s.format), "employee_id": s.employeeID}, nil
+}
+
+func TestRegisterSubjectFormat(t *testing.T) {
+	const formatEmployeeID Format = "x-employee-id"
+
+	RegisterSubjectFormat(formatEmployeeID, func(data []byte) (Subject, error) {
+		var subject employeeIDSubject
+		if err := json.Unmarshal(data, &subject); err != nil {
+			return nil, err
+		}
+
+		return &subject, nil
+	})
+
+	if !IsSubjectFormatRegistered(formatEmployeeID) {
+		t.Fatal("expected format to be registered")
+	}
+
+	parsed, err := ParseSubject([]byte(`{"format":"x-employee-id","employee_id":"E-42"}`))
+	if err != nil {
+		t.Fatalf("ParseSubject() error = %v", err)
+	}
+
+	if parsed.(*employeeIDSubject).employeeID != "E-42" {
+		t.Errorf("unexpected subject: %+v", parsed)
+	}
+
+	// Registered formats are also available as complex subject components
+	complexSubject, err := ParseSubject([]byte(`{"format":"complex","user":{"format":"x-employee-id","employee_id":"E-42"}}`))
+	if err != nil {
+		t.Fatalf("ParseSubject() error = %v", err)
+	}
+
+	if user, ok := complexSubject.(ComplexSubject).UserComponent(); !ok || user.Format() != formatEmployeeID {
+		t.Errorf("unexpected user component: %v", user)
+	}
+}
@@ -107,8 +107,8 @@ func (ps *PhoneSubject) Phone() string {
 
 func (ps *PhoneSubject) MarshalJSON() ([]byte, error) {
 	m := map[string]string{
-		"format": string(ps.format),
-		"phone":  ps.phone,
+		"format":       string(ps.format),
+		"phone_number": ps.phone,
 	}
 
 	return json.Marshal(m)
@@ -124,8 +124,14 @@ func (ps *PhoneSubject) UnmarshalJSON(data []byte) error {
 		return NewError(ErrCodeInvalidFormat, "invalid format for phone subject", "format")
 	}
 
+	// RFC 9493 names the member phone_number; earlier versions of this package wrote phone
+	phone, ok := raw["phone_number"]
+	if !ok {
+		phone = raw["phone"]
+	}
+
 	ps.format = FormatPhone
-	ps.phone = strings.TrimSpace(raw["phone"])
+	ps.phone = strings.TrimSpace(phone)
 
 	return nil
 }
@@ -142,8 +148,8 @@ func (ps *PhoneSubject) Validate() error {
 
 func (ps *PhoneSubject) Payload() (map[string]interface{}, error) {
     return map[string]interface{}{
-        "format": string(ps.format),
-        "phone":  ps.phone,
+        "format":       string(ps.format),
+        "phone_number": ps.phone,
     }, nil
 }
 
@@ -0,0 +1,44 @@
+package token
+
+import (
+	"encoding/json"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+)
+
+// DecodeOptions controls how a SecEvent is decoded from JSON
+type DecodeOptions struct {
+	// Registry resolves event parsers; event.DefaultRegistry is used when nil
+	Registry *event.Registry
+
+	// LenientEvents decodes events with no registered parser as *event.RawEvent
+	// instead of failing the whole token
+	LenientEvents bool
+
+	// LegacySubject accepts the layout of early CAEP drafts, where each event carries its
+	// subject in a "subject" member. The event-level subject is removed from the event and,
+	// when sub_id is absent, used as sub_id; all events must then name the same subject.
+	LegacySubject bool
+}
+
+// decode rewrites claims to the layout expected by SecEvent and MultiSecEvent
+func (o DecodeOptions) decode(data []byte) ([]byte, error) {
+	if !o.LegacySubject {
+		return data, nil
+	}
+
+	return fromLegacyLayout(data)
+}
+
+func (o DecodeOptions) parseEvent(eventType event.EventType, data json.RawMessage) (event.Event, error) {
+	registry := o.Registry
+	if registry == nil {
+		registry = event.DefaultRegistry
+	}
+
+	if o.LenientEvents {
+		return registry.ParseLenient(eventType, data)
+	}
+
+	return registry.Parse(eventType, data)
+}
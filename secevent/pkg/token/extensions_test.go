@@ -0,0 +1,178 @@
+package token_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+const extendedSecEventJSON = `{
+	"iss": "https://issuer.example.com",
+	"jti": "ext-1",
+	"iat": 1700000000,
+	"toe": 1699999990,
+	"vendor": {"tenant": "acme", "region": "eu"},
+	"sub_id": {"format": "email", "email": "user@example.com"},
+	"events": {
+		"https://schemas.openid.net/secevent/caep/event-type/session-revoked": {
+			"event_timestamp": 1700000000,
+			"x_vendor_session": "s-42"
+		}
+	}
+}`
+
+func TestSecEvent_Extensions(t *testing.T) {
+	var secEvent token.SecEvent
+	if err := json.Unmarshal([]byte(extendedSecEventJSON), &secEvent); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if len(secEvent.Extensions) != 2 {
+		t.Fatalf("Extensions = %v, want toe and vendor", secEvent.Extensions)
+	}
+
+	if string(secEvent.Extensions["toe"]) != "1699999990" {
+		t.Errorf("toe = %s", secEvent.Extensions["toe"])
+	}
+
+	revoked, ok := secEvent.Event.(*caep.SessionRevokedEvent)
+	if !ok {
+		t.Fatalf("event = %T", secEvent.Event)
+	}
+
+	if got := string(revoked.GetExtensions()["x_vendor_session"]); got != `"s-42"` {
+		t.Errorf("event extension = %s", got)
+	}
+
+	if _, ok := revoked.GetExtensions()["event_timestamp"]; ok {
+		t.Error("modeled member event_timestamp reported as extension")
+	}
+
+	marshaled, err := json.Marshal(&secEvent)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got, want map[string]interface{}
+	if err := json.Unmarshal(marshaled, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if err := json.Unmarshal([]byte(extendedSecEventJSON), &want); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	gotJSON, _ := json.Marshal(got)
+	wantJSON, _ := json.Marshal(want)
+
+	if string(gotJSON) != string(wantJSON) {
+		t.Errorf("round trip lost members\ngot:  %s\nwant: %s", gotJSON, wantJSON)
+	}
+}
+
+func TestMultiSecEvent_Extensions(t *testing.T) {
+	var multi token.MultiSecEvent
+	if err := json.Unmarshal([]byte(extendedSecEventJSON), &multi); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	multi.WithExtension("iss", json.RawMessage(`"https://ignored.example.com"`))
+
+	marshaled, err := json.Marshal(&multi)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var got struct {
+		Issuer string                                `json:"iss"`
+		Vendor map[string]string                     `json:"vendor"`
+		Events map[string]map[string]json.RawMessage `json:"events"`
+	}
+
+	if err := json.Unmarshal(marshaled, &got); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if got.Issuer != "https://issuer.example.com" {
+		t.Errorf("extension overrode modeled claim: iss = %s", got.Issuer)
+	}
+
+	if got.Vendor["tenant"] != "acme" {
+		t.Errorf("vendor = %v", got.Vendor)
+	}
+
+	payload := got.Events[string(caep.EventTypeSessionRevoked)]
+	if string(payload["x_vendor_session"]) != `"s-42"` {
+		t.Errorf("event payload = %v", payload)
+	}
+}
+
+func TestSecEvent_WithExtension(t *testing.T) {
+	evt := caep.NewSessionRevokedEvent()
+	evt.SetExtension("x_vendor_reason_code", json.RawMessage(`17`))
+
+	userID, _ := subject.NewOpaqueSubject("u-1")
+
+	secEvent := token.NewSecEvent().
+		WithIssuer("https://issuer.example.com").
+		WithID("ext-2").
+		WithSubject(userID).
+		WithEvent(evt).
+		WithExtension("toe", json.RawMessage(`1700000000`))
+
+	marshaled, err := json.Marshal(secEvent)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var roundTrip token.SecEvent
+	if err := json.Unmarshal(marshaled, &roundTrip); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if string(roundTrip.Extensions["toe"]) != "1700000000" {
+		t.Errorf("toe = %s", roundTrip.Extensions["toe"])
+	}
+
+	revoked := roundTrip.Event.(*caep.SessionRevokedEvent)
+	if string(revoked.GetExtensions()["x_vendor_reason_code"]) != "17" {
+		t.Errorf("event extensions = %v", revoked.GetExtensions())
+	}
+}
+
+func TestSecEvent_ExtensionsCaseInsensitiveMembers(t *testing.T) {
+	data := []byte(`{
+		"ISS": "https://issuer.example.com",
+		"jti": "ext-3",
+		"sub_id": {"format": "opaque", "id": "u-1"},
+		"events": {
+			"https://schemas.openid.net/secevent/caep/event-type/session-revoked": {
+				"EVENT_TIMESTAMP": 5,
+				"vendor": 1
+			}
+		}
+	}`)
+
+	var secEvent token.SecEvent
+	if err := json.Unmarshal(data, &secEvent); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if len(secEvent.Extensions) != 0 {
+		t.Errorf("Extensions = %v, want none", secEvent.Extensions)
+	}
+
+	revoked := secEvent.Event.(*caep.SessionRevokedEvent)
+
+	payload, err := json.Marshal(revoked)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	if want := `{"event_timestamp":5,"vendor":1}`; string(payload) != want {
+		t.Errorf("payload = %s, want %s", payload, want)
+	}
+}
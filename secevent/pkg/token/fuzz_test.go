@@ -0,0 +1,66 @@
+package token_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	_ "github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	_ "github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/risc"
+	_ "github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/ssf"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+var fuzzSeeds = []string{
+	`{"iss":"https://issuer.example.com","jti":"1","iat":1700000000,"sub_id":{"format":"email","email":"user@example.com"},"events":{"https://schemas.openid.net/secevent/caep/event-type/session-revoked":{}}}`,
+	`{"iss":"https://issuer.example.com","jti":"2","aud":["a","b"],"txn":"t","sub_id":{"format":"complex","user":{"format":"opaque","id":"u"}},"events":{"https://schemas.openid.net/secevent/risc/event-type/account-disabled":{"reason":"hijacking"}}}`,
+	`{"iss":"https://issuer.example.com","jti":"3","sub_id":{"format":"opaque","id":"u"},"events":{"https://schemas.openid.net/secevent/caep/event-type/session-revoked":{},"https://schemas.openid.net/secevent/caep/event-type/credential-change":{"credential_type":"password","change_type":"update"}}}`,
+	`{"iss":"https://issuer.example.com","jti":"4","sub_id":{"format":"opaque","id":"u"},"events":{}}`,
+}
+
+func FuzzSecEventUnmarshal(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var secEvent token.SecEvent
+		if err := json.Unmarshal(data, &secEvent); err != nil {
+			return
+		}
+
+		if err := secEvent.Validate(); err != nil {
+			t.Fatalf("unmarshaled SecEvent is invalid: %v", err)
+		}
+
+		marshaled, err := json.Marshal(&secEvent)
+		if err != nil {
+			t.Fatalf("failed to marshal SecEvent: %v", err)
+		}
+
+		var reparsed token.SecEvent
+		if err := json.Unmarshal(marshaled, &reparsed); err != nil {
+			t.Fatalf("failed to unmarshal marshaled SecEvent %s: %v", marshaled, err)
+		}
+	})
+}
+
+func FuzzMultiSecEventUnmarshal(f *testing.F) {
+	for _, seed := range fuzzSeeds {
+		f.Add([]byte(seed))
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var secEvent token.MultiSecEvent
+		if err := json.Unmarshal(data, &secEvent); err != nil {
+			return
+		}
+
+		if err := secEvent.Validate(); err != nil {
+			t.Fatalf("unmarshaled MultiSecEvent is invalid: %v", err)
+		}
+
+		if _, err := json.Marshal(&secEvent); err != nil {
+			t.Fatalf("failed to marshal MultiSecEvent: %v", err)
+		}
+	})
+}
@@ -0,0 +1,226 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+)
+
+// EncodeOptions controls how a SecEvent is marshaled
+type EncodeOptions struct {
+	// LegacySubject emits the layout of early CAEP drafts: the subject is written into each
+	// event as "subject", using subject_type instead of format, and sub_id is omitted
+	LegacySubject bool
+}
+
+// encode rewrites marshaled claims to the layout selected by the options
+func (o EncodeOptions) encode(data []byte) ([]byte, error) {
+	if !o.LegacySubject {
+		return data, nil
+	}
+
+	return toLegacyLayout(data)
+}
+
+// legacyMember is the event payload member that held the subject in early CAEP drafts
+const legacyMember = "subject"
+
+// legacyFormats maps subject_type values of early drafts that differ from today's formats
+var legacyFormats = map[string]string{
+	"iss-sub":      string(subject.FormatIssuerSub),
+	"phone-number": string(subject.FormatPhone),
+}
+
+// fromLegacyLayout rewrites claims that carry the subject inside their events to the current
+// layout. Event-level subjects are removed from the payloads and must all be the same subject;
+// when sub_id is present they must equal it, otherwise sub_id is set to the event-level subject.
+func fromLegacyLayout(data []byte) ([]byte, error) {
+	var claims map[string]json.RawMessage
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return nil, err
+	}
+
+	var events map[string]json.RawMessage
+	if err := json.Unmarshal(claims["events"], &events); err != nil || len(events) == 0 {
+		return data, nil
+	}
+
+	var lifted json.RawMessage
+	var liftedSubject subject.Subject
+
+	rawSubID, hasSubID := claims["sub_id"]
+	if hasSubID {
+		var err error
+		if liftedSubject, err = subject.ParseSubject(rawSubID); err != nil {
+			return nil, fmt.Errorf("failed to parse sub_id: %w", err)
+		}
+	}
+
+	changed := false
+
+	for eventType, payload := range events {
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(payload, &members); err != nil {
+			continue
+		}
+
+		rawSubject, ok := members[legacyMember]
+		if !ok {
+			continue
+		}
+
+		normalized, err := normalizeLegacySubject(rawSubject)
+		if err != nil {
+			return nil, fmt.Errorf("invalid subject in event %s: %w", eventType, err)
+		}
+
+		eventSubject, err := subject.ParseSubject(normalized)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse subject in event %s: %w", eventType, err)
+		}
+
+		switch {
+		case liftedSubject == nil:
+			lifted, liftedSubject = normalized, eventSubject
+		case subject.Equal(liftedSubject, eventSubject):
+		case hasSubID:
+			return nil, fmt.Errorf("subject in event %s differs from sub_id", eventType)
+		default:
+			return nil, fmt.Errorf("events carry different subjects; they cannot be lifted to sub_id")
+		}
+
+		delete(members, legacyMember)
+
+		if events[eventType], err = json.Marshal(members); err != nil {
+			return nil, err
+		}
+
+		changed = true
+	}
+
+	if !changed {
+		return data, nil
+	}
+
+	if !hasSubID {
+		claims["sub_id"] = lifted
+	}
+
+	eventsJSON, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+
+	claims["events"] = eventsJSON
+
+	return json.Marshal(claims)
+}
+
+// toLegacyLayout moves sub_id into each event of marshaled claims
+func toLegacyLayout(data []byte) ([]byte, error) {
+	var claims map[string]json.RawMessage
+	if err := json.Unmarshal(data, &claims); err != nil {
+		return nil, err
+	}
+
+	rawSubject, ok := claims["sub_id"]
+	if !ok {
+		return data, nil
+	}
+
+	legacySubject, err := legacySubjectJSON(rawSubject)
+	if err != nil {
+		return nil, err
+	}
+
+	var events map[string]json.RawMessage
+	if err := json.Unmarshal(claims["events"], &events); err != nil {
+		return nil, fmt.Errorf("failed to read events: %w", err)
+	}
+
+	for eventType, payload := range events {
+		var members map[string]json.RawMessage
+		if err := json.Unmarshal(payload, &members); err != nil {
+			return nil, fmt.Errorf("event %s is not a JSON object: %w", eventType, err)
+		}
+
+		if members == nil {
+			members = make(map[string]json.RawMessage)
+		}
+
+		members[legacyMember] = legacySubject
+
+		if events[eventType], err = json.Marshal(members); err != nil {
+			return nil, err
+		}
+	}
+
+	eventsJSON, err := json.Marshal(events)
+	if err != nil {
+		return nil, err
+	}
+
+	claims["events"] = eventsJSON
+	delete(claims, "sub_id")
+
+	return json.Marshal(claims)
+}
+
+// normalizeLegacySubject converts subject_type members (at any depth, to cover complex
+// subjects) to format
+func normalizeLegacySubject(data json.RawMessage) (json.RawMessage, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(renameFormat(value, "subject_type", "format", legacyFormats))
+}
+
+// legacySubjectJSON converts format members of a sub_id to the subject_type of early drafts
+func legacySubjectJSON(data json.RawMessage) (json.RawMessage, error) {
+	var value interface{}
+	if err := json.Unmarshal(data, &value); err != nil {
+		return nil, err
+	}
+
+	formats := make(map[string]string, len(legacyFormats))
+	for legacy, current := range legacyFormats {
+		formats[current] = legacy
+	}
+
+	return json.Marshal(renameFormat(value, "format", "subject_type", formats))
+}
+
+func renameFormat(value interface{}, from, to string, formats map[string]string) interface{} {
+	switch v := value.(type) {
+	case map[string]interface{}:
+		for name, member := range v {
+			v[name] = renameFormat(member, from, to, formats)
+		}
+
+		if format, ok := v[from]; ok {
+			if _, exists := v[to]; !exists {
+				if name, isString := format.(string); isString {
+					if mapped, found := formats[name]; found {
+						format = mapped
+					}
+				}
+
+				v[to] = format
+				delete(v, from)
+			}
+		}
+
+		return v
+	case []interface{}:
+		for i, member := range v {
+			v[i] = renameFormat(member, from, to, formats)
+		}
+
+		return v
+	default:
+		return value
+	}
+}
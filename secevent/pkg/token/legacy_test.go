@@ -0,0 +1,164 @@
+package token_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/schemes/caep"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/subject"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+const legacySecEventJSON = `{
+	"iss": "https://issuer.example.com",
+	"jti": "legacy-1",
+	"iat": 1700000000,
+	"events": {
+		"https://schemas.openid.net/secevent/caep/event-type/session-revoked": {
+			"subject": {"subject_type": "iss-sub", "issuer": "https://idp.example.com", "sub": "u-1"},
+			"event_timestamp": 1700000000
+		},
+		"https://schemas.openid.net/secevent/caep/event-type/token-claims-change": {
+			"subject": {"subject_type": "iss-sub", "issuer": "https://idp.example.com", "sub": "u-1"},
+			"claims": {"role": "admin"}
+		}
+	}
+}`
+
+func TestSecEvent_LegacySubject(t *testing.T) {
+	var strict token.MultiSecEvent
+	if err := json.Unmarshal([]byte(legacySecEventJSON), &strict); err == nil {
+		t.Fatal("Unmarshal() accepted a legacy token without LegacySubject")
+	}
+
+	multi := token.NewMultiSecEvent().WithDecodeOptions(token.DecodeOptions{LegacySubject: true})
+	if err := json.Unmarshal([]byte(legacySecEventJSON), multi); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	want, _ := subject.NewIssuerSubSubject("https://idp.example.com", "u-1")
+	if !subject.Equal(multi.Subject, want) {
+		t.Errorf("Subject = %v, want %v", multi.Subject, want)
+	}
+
+	revoked := multi.Events[caep.EventTypeSessionRevoked].(*caep.SessionRevokedEvent)
+	if _, ok := revoked.GetExtensions()["subject"]; ok {
+		t.Error("lifted subject still reported as an event extension")
+	}
+}
+
+func TestSecEvent_LegacySubjectMismatch(t *testing.T) {
+	data := []byte(`{
+		"iss": "https://issuer.example.com",
+		"jti": "legacy-2",
+		"events": {
+			"https://schemas.openid.net/secevent/caep/event-type/session-revoked": {
+				"subject": {"subject_type": "email", "email": "a@example.com"}
+			},
+			"https://schemas.openid.net/secevent/caep/event-type/credential-change": {
+				"subject": {"subject_type": "email", "email": "b@example.com"},
+				"credential_type": "password",
+				"change_type": "update"
+			}
+		}
+	}`)
+
+	multi := token.NewMultiSecEvent().WithDecodeOptions(token.DecodeOptions{LegacySubject: true})
+	if err := json.Unmarshal(data, multi); err == nil {
+		t.Error("Unmarshal() accepted events with different subjects")
+	}
+}
+
+func TestSecEvent_LegacySubjectWithSubID(t *testing.T) {
+	tests := []struct {
+		name         string
+		eventSubject string
+		wantErr      bool
+	}{
+		{name: "equal", eventSubject: `{"subject_type": "email", "email": "a@example.com"}`},
+		{name: "different", eventSubject: `{"subject_type": "email", "email": "b@example.com"}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data := []byte(`{
+				"iss": "https://issuer.example.com",
+				"jti": "legacy-3",
+				"sub_id": {"format": "email", "email": "a@example.com"},
+				"events": {
+					"https://schemas.openid.net/secevent/caep/event-type/session-revoked": {
+						"subject": ` + tt.eventSubject + `
+					}
+				}
+			}`)
+
+			secEvent := token.NewSecEvent().WithDecodeOptions(token.DecodeOptions{LegacySubject: true})
+
+			err := json.Unmarshal(data, secEvent)
+			if tt.wantErr {
+				if err == nil {
+					t.Error("Unmarshal() accepted an event subject that differs from sub_id")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("Unmarshal() error = %v", err)
+			}
+
+			want, _ := subject.NewEmailSubject("a@example.com")
+			if !subject.Equal(secEvent.Subject, want) {
+				t.Errorf("Subject = %v, want %v", secEvent.Subject, want)
+			}
+
+			revoked := secEvent.Event.(*caep.SessionRevokedEvent)
+			if _, ok := revoked.GetExtensions()["subject"]; ok {
+				t.Error("event subject equal to sub_id still reported as an event extension")
+			}
+		})
+	}
+}
+
+func TestSecEvent_LegacySubjectRoundTrip(t *testing.T) {
+	userPhone, _ := subject.NewPhoneSubject("+12065550100")
+
+	secEvent := token.NewSecEvent().
+		WithIssuer("https://issuer.example.com").
+		WithID("legacy-3").
+		WithSubject(userPhone).
+		WithEvent(caep.NewSessionRevokedEvent()).
+		WithEncodeOptions(token.EncodeOptions{LegacySubject: true})
+
+	marshaled, err := json.Marshal(secEvent)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var claims struct {
+		SubjectID json.RawMessage                                  `json:"sub_id"`
+		Events    map[string]map[string]map[string]json.RawMessage `json:"events"`
+	}
+
+	if err := json.Unmarshal(marshaled, &claims); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if claims.SubjectID != nil {
+		t.Errorf("sub_id = %s, want it omitted", claims.SubjectID)
+	}
+
+	legacySubject := claims.Events[string(caep.EventTypeSessionRevoked)]["subject"]
+	if got := string(legacySubject["subject_type"]); got != `"phone-number"` {
+		t.Errorf("subject_type = %s, want \"phone-number\"", got)
+	}
+
+	roundTrip := token.NewSecEvent().WithDecodeOptions(token.DecodeOptions{LegacySubject: true})
+	if err := json.Unmarshal(marshaled, roundTrip); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	if !subject.Equal(roundTrip.Subject, userPhone) {
+		t.Errorf("Subject = %v, want %v", roundTrip.Subject, userPhone)
+	}
+}
@@ -0,0 +1,60 @@
+package token_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/event"
+	"github.com/sgnl-ai/caep.dev/secevent/pkg/token"
+)
+
+func TestSecEvent_RawEventMarshal(t *testing.T) {
+	original := []byte(`{ "z": "a&b <c>",  "a": 1.50, "u": "é" }`)
+
+	data := []byte(`{
+		"iss": "https://issuer.example.com",
+		"jti": "raw-1",
+		"sub_id": {"format": "opaque", "id": "u-1"},
+		"events": {"https://vendor.example.com/event-type/unknown": ` + string(original) + `}
+	}`)
+
+	secEvent := token.NewSecEvent().WithDecodeOptions(token.DecodeOptions{LenientEvents: true})
+	if err := json.Unmarshal(data, secEvent); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	rawEvent, ok := secEvent.Event.(*event.RawEvent)
+	if !ok {
+		t.Fatalf("event = %T, want *event.RawEvent", secEvent.Event)
+	}
+
+	if !bytes.Equal(rawEvent.Raw(), original) {
+		t.Errorf("Raw() = %s, want %s", rawEvent.Raw(), original)
+	}
+
+	marshaled, err := json.Marshal(secEvent)
+	if err != nil {
+		t.Fatalf("Marshal() error = %v", err)
+	}
+
+	var claims struct {
+		Events map[string]json.RawMessage `json:"events"`
+	}
+
+	if err := json.Unmarshal(marshaled, &claims); err != nil {
+		t.Fatalf("Unmarshal() error = %v", err)
+	}
+
+	var compacted, want bytes.Buffer
+	if err := json.Compact(&compacted, original); err != nil {
+		t.Fatal(err)
+	}
+
+	json.HTMLEscape(&want, compacted.Bytes())
+
+	got := claims.Events["https://vendor.example.com/event-type/unknown"]
+	if !bytes.Equal(got, want.Bytes()) {
+		t.Errorf("marshaled payload = %s, want %s", got, want.Bytes())
+	}
+}
@@ -3,6 +3,7 @@ package token
 import (
 	"encoding/json"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
@@ -20,6 +21,12 @@ type MultiSecEvent struct {
 
 	// Optional Claims
 	TransactionID *string `json:"txn,omitempty"` // OPTIONAL
+
+	// Extensions holds claims this type does not model, e.g. toe or vendor claims
+	Extensions map[string]json.RawMessage `json:"-"`
+
+	decodeOptions DecodeOptions
+	encodeOptions EncodeOptions
 }
 
 func NewMultiSecEvent() *MultiSecEvent {
@@ -101,6 +108,32 @@ func (s *MultiSecEvent) WithTransactionID(txn string) *MultiSecEvent {
 	return s
 }
 
+// WithExtension adds a claim this type does not model. Modeled claims of the same name take
+// precedence when the SecEvent is marshaled.
+func (s *MultiSecEvent) WithExtension(name string, value json.RawMessage) *MultiSecEvent {
+	if s.Extensions == nil {
+		s.Extensions = make(map[string]json.RawMessage)
+	}
+
+	s.Extensions[name] = value
+
+	return s
+}
+
+// WithDecodeOptions sets the options used when the SecEvent is unmarshaled
+func (s *MultiSecEvent) WithDecodeOptions(opts DecodeOptions) *MultiSecEvent {
+	s.decodeOptions = opts
+
+	return s
+}
+
+// WithEncodeOptions sets the options used when the SecEvent is marshaled
+func (s *MultiSecEvent) WithEncodeOptions(opts EncodeOptions) *MultiSecEvent {
+	s.encodeOptions = opts
+
+	return s
+}
+
 func (s *MultiSecEvent) GetExpirationTime() (*jwt.NumericDate, error) {
 	return nil, nil // SecEvent doesn't use expiration time
 }
@@ -125,6 +158,21 @@ func (s *MultiSecEvent) GetAudience() (jwt.ClaimStrings, error) {
 	return s.Audience, nil
 }
 
+func (s *MultiSecEvent) MarshalJSON() ([]byte, error) {
+	type Alias MultiSecEvent
+
+	data, err := json.Marshal((*Alias)(s))
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err = event.MergeExtensions(data, s.Extensions); err != nil {
+		return nil, err
+	}
+
+	return s.encodeOptions.encode(data)
+}
+
 func (s *MultiSecEvent) UnmarshalJSON(data []byte) error {
 	type Alias MultiSecEvent
 
@@ -136,6 +184,11 @@ func (s *MultiSecEvent) UnmarshalJSON(data []byte) error {
 		Alias: (*Alias)(s),
 	}
 
+	data, err := s.decodeOptions.decode(data)
+	if err != nil {
+		return err
+	}
+
 	if err := json.Unmarshal(data, aux); err != nil {
 		return err
 	}
@@ -154,7 +207,7 @@ func (s *MultiSecEvent) UnmarshalJSON(data []byte) error {
 	}
 
 	for eventType, eventData := range aux.Events {
-		parsedEvent, err := event.ParseEvent(eventType, eventData)
+		parsedEvent, err := s.decodeOptions.parseEvent(eventType, eventData)
 		if err != nil {
 			return fmt.Errorf("failed to parse event of type %s: %w", eventType, err)
 		}
@@ -162,6 +215,13 @@ func (s *MultiSecEvent) UnmarshalJSON(data []byte) error {
 		s.Events[eventType] = parsedEvent
 	}
 
+	extensions, err := event.UnknownMembers(data, isRegisteredClaim)
+	if err != nil {
+		return err
+	}
+
+	s.Extensions = extensions
+
 	return s.Validate()
 }
 
@@ -174,6 +234,12 @@ type SecEvent struct {
 
 	// Optional Claims
 	TransactionID *string `json:"txn,omitempty"` // OPTIONAL
+
+	// Extensions holds claims this type does not model, e.g. toe or vendor claims
+	Extensions map[string]json.RawMessage `json:"-"`
+
+	decodeOptions DecodeOptions
+	encodeOptions EncodeOptions
 }
 
 func NewSecEvent() *SecEvent {
@@ -252,6 +318,32 @@ func (s *SecEvent) WithTransactionID(txn string) *SecEvent {
 	return s
 }
 
+// WithExtension adds a claim this type does not model. Modeled claims of the same name take
+// precedence when the SecEvent is marshaled.
+func (s *SecEvent) WithExtension(name string, value json.RawMessage) *SecEvent {
+	if s.Extensions == nil {
+		s.Extensions = make(map[string]json.RawMessage)
+	}
+
+	s.Extensions[name] = value
+
+	return s
+}
+
+// WithDecodeOptions sets the options used when the SecEvent is unmarshaled
+func (s *SecEvent) WithDecodeOptions(opts DecodeOptions) *SecEvent {
+	s.decodeOptions = opts
+
+	return s
+}
+
+// WithEncodeOptions sets the options used when the SecEvent is marshaled
+func (s *SecEvent) WithEncodeOptions(opts EncodeOptions) *SecEvent {
+	s.encodeOptions = opts
+
+	return s
+}
+
 func (s *SecEvent) GetExpirationTime() (*jwt.NumericDate, error) {
 	return nil, nil // SecEvent doesn't use expiration time
 }
@@ -288,10 +380,24 @@ func (s *SecEvent) MarshalJSON() ([]byte, error) {
 	}
 
 	if s.Event != nil {
-		temp.Events[s.Event.Type()] = s.Event.Payload()
+		payload, err := event.MarshalPayload(s.Event)
+		if err != nil {
+			return nil, err
+		}
+
+		temp.Events[s.Event.Type()] = json.RawMessage(payload)
+	}
+
+	data, err := json.Marshal(temp)
+	if err != nil {
+		return nil, err
+	}
+
+	if data, err = event.MergeExtensions(data, s.Extensions); err != nil {
+		return nil, err
 	}
 
-	return json.Marshal(temp)
+	return s.encodeOptions.encode(data)
 }
 
 func (s *SecEvent) UnmarshalJSON(data []byte) error {
@@ -305,6 +411,11 @@ func (s *SecEvent) UnmarshalJSON(data []byte) error {
 		Alias: (*Alias)(s),
 	}
 
+	data, err := s.decodeOptions.decode(data)
+	if err != nil {
+		return err
+	}
+
 	if err := json.Unmarshal(data, aux); err != nil {
 		return err
 	}
@@ -329,12 +440,34 @@ func (s *SecEvent) UnmarshalJSON(data []byte) error {
 		eventData = d
 	}
 
-	parsedEvent, err := event.ParseEvent(eventType, eventData)
+	parsedEvent, err := s.decodeOptions.parseEvent(eventType, eventData)
 	if err != nil {
 		return err
 	}
 
 	s.Event = parsedEvent
 
+	extensions, err := event.UnknownMembers(data, isRegisteredClaim)
+	if err != nil {
+		return err
+	}
+
+	s.Extensions = extensions
+
 	return s.Validate()
 }
+
+// registeredClaims are the claims modeled by SecEvent and MultiSecEvent
+var registeredClaims = []string{"iss", "sub", "aud", "exp", "nbf", "iat", "jti", "events", "sub_id", "txn"}
+
+// isRegisteredClaim reports whether a claim is modeled by SecEvent and MultiSecEvent. Names are
+// compared case-insensitively, as encoding/json does when it decodes them into the claim fields.
+func isRegisteredClaim(name string) bool {
+	for _, claim := range registeredClaims {
+		if strings.EqualFold(claim, name) {
+			return true
+		}
+	}
+
+	return false
+}